@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"net/http"
 	"reflect"
 
@@ -31,6 +29,8 @@ type AccessControlsServiceOp struct {
 
 var _ AccessControlsService = &AccessControlsServiceOp{}
 
+var accessControlsCRUD = crud[AccessControl]{name: "AccessControl", path: bucketAccessControlsBasePath, root: "access_control"}
+
 type AccessControl struct {
 	BucketID       int         `json:"bucket_id,omitempty"`
 	ServerType     string      `json:"server_type,omitempty"`
@@ -50,10 +50,6 @@ type AccessControlCreateRequest struct {
 	Limits     *Limits `json:"limits,omitempty"`
 }
 
-type accessControlRoot struct {
-	AccessControl *AccessControl `json:"access_control"`
-}
-
 type AccessControlDeleteRequest AccessControlCreateRequest
 type AccessControlEditRequest AccessControlCreateRequest
 
@@ -67,25 +63,7 @@ func (s *AccessControlsServiceOp) List(ctx context.Context, id int, opt *ListOpt
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(bucketAccessControlsBasePath, id) + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]AccessControl
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]AccessControl, len(out))
-	for i := range arr {
-		arr[i] = out[i]["access_control"]
-	}
-
-	return arr, resp, err
+	return accessControlsCRUD.at(id).list(ctx, s.client, opt)
 }
 
 // Create AccessControl.
@@ -94,42 +72,27 @@ func (s *AccessControlsServiceOp) Create(ctx context.Context, createRequest *Acc
 		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(bucketAccessControlsBasePath, createRequest.BucketID) + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("AccessControl [Create]  req: ", req)
-
-	root := new(accessControlRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.AccessControl, resp, err
+	r := accessControlsCRUD.at(createRequest.BucketID)
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), createRequest)
 }
 
 // Delete AccessControl.
 func (s *AccessControlsServiceOp) Delete(ctx context.Context, deleteRequest *AccessControlDeleteRequest, meta interface{}) (*Response, error) {
-	if deleteRequest.BucketID < 1 {
-		return nil, godo.NewArgError("bucketID", "cannot be less than 1")
+	if deleteRequest == nil {
+		return nil, godo.NewArgError("deleteRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(bucketAccessControlsBasePath, deleteRequest.BucketID) + apiFormat
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
+	if deleteRequest.BucketID < 1 {
+		return nil, godo.NewArgError("bucketID", "cannot be less than 1")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, deleteRequest)
+	r := accessControlsCRUD.at(deleteRequest.BucketID)
+	path, err := addOptions(r.collection(), meta)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("AccessControl [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.send(ctx, s.client, "Delete", http.MethodDelete, path, deleteRequest)
 }
 
 // Edit AccessControl.
@@ -138,21 +101,8 @@ func (s *AccessControlsServiceOp) Edit(ctx context.Context, editRequest *AccessC
 		return nil, godo.NewArgError("editRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(bucketAccessControlsBasePath, editRequest.BucketID) + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("AccessControl [Edit]  req: ", req)
-
-	root := new(accessControlRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, err
+	r := accessControlsCRUD.at(editRequest.BucketID)
+	return r.send(ctx, s.client, "Edit", http.MethodPost, r.collection(), editRequest)
 }
 
 func (obj *AccessControl) EqualFilter(filter interface{}) bool {
@@ -184,14 +134,8 @@ func (obj *AccessControl) equal(filter interface{}) bool {
 type Limits map[string]interface{}
 
 func LimitsRef(serverType string, resourceType string) *Limits {
-	log.Printf("AccessControl [LimitsRef]  serverType: '%s'  resourceType: '%s'", serverType, resourceType)
-
 	if st, ok := (*AccessControls)[serverType]; ok {
-		log.Printf("AccessControl [LimitsRef]  serverType: '%+v'", &st)
-
 		if rt, ok := (*st)[resourceType]; ok {
-			log.Printf("AccessControl [LimitsRef]  resourceType: '%+v'", &rt)
-
 			return rt
 		}
 	}
@@ -0,0 +1,184 @@
+package onappgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes a mutating request made through the client.
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+
+	// User is the API user the request was made on behalf of
+	User string `json:"user,omitempty"`
+
+	Method string `json:"method"`
+
+	// Path is relative to the base URL and includes the query
+	Path string `json:"path"`
+
+	// Body is the JSON request body with secrets redacted
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// StatusCode is 0 when no response was received
+	StatusCode int    `json:"status_code,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+
+	// TransactionID is set for actions which return a Transaction
+	TransactionID int    `json:"transaction_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// AuditSink receives the audit records of the client.
+type AuditSink interface {
+	Audit(record AuditRecord) error
+}
+
+// AuditSinkFunc is an adapter to use ordinary functions as AuditSink.
+type AuditSinkFunc func(record AuditRecord) error
+
+// Audit calls f(record).
+func (f AuditSinkFunc) Audit(record AuditRecord) error {
+	return f(record)
+}
+
+// SetAuditSink is a client option for emitting an AuditRecord for every POST,
+// PUT, PATCH and DELETE request. Failures of the sink are logged, they don't
+// fail the request.
+func SetAuditSink(sink AuditSink) ClientOpt {
+	return func(c *Client) error {
+		c.auditSink = sink
+		return nil
+	}
+}
+
+// JSONLinesAuditSink writes audit records as JSON lines, it is safe for
+// concurrent use.
+type JSONLinesAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ AuditSink = &JSONLinesAuditSink{}
+
+// NewJSONLinesAuditSink returns a sink writing to w.
+func NewJSONLinesAuditSink(w io.Writer) *JSONLinesAuditSink {
+	return &JSONLinesAuditSink{w: w}
+}
+
+// OpenJSONLinesAuditFile returns a sink appending to the file, which is created
+// if needed. The caller should Close the sink when done.
+func OpenJSONLinesAuditFile(path string) (*JSONLinesAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLinesAuditSink(f), nil
+}
+
+// Audit writes the record as a single line.
+func (s *JSONLinesAuditSink) Audit(record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (s *JSONLinesAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+type transactionAuditKey struct{}
+
+// withTransactionAudit returns a context for action requests whose audit
+// record is emitted by lastTransaction, so that it includes the transaction.
+func withTransactionAudit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionAuditKey{}, true)
+}
+
+// audit emits the record of a mutating request. Records of successful action
+// requests are kept on the response until auditTransaction is called.
+func (c *Client) audit(ctx context.Context, started time.Time, req *http.Request, resp *Response, err error) {
+	if c.auditSink == nil || errors.Is(err, ErrDryRun) {
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return
+	}
+
+	record := AuditRecord{
+		Time:      started.UTC(),
+		Method:    req.Method,
+		Path:      c.relativePath(req),
+		Body:      jsonRequestBody(req),
+		RequestID: req.Header.Get(headerRequestID),
+	}
+
+	if skip, _ := ctx.Value(skipAuthorizationKey{}).(bool); !skip && c.credentials != nil {
+		record.User = c.credentials.Username()
+	}
+
+	if resp != nil && resp.Response != nil {
+		record.StatusCode = resp.StatusCode
+		if resp.RequestID != "" {
+			record.RequestID = resp.RequestID
+		}
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	if deferred, _ := ctx.Value(transactionAuditKey{}).(bool); deferred && err == nil && resp != nil {
+		resp.audit = &record
+		return
+	}
+
+	c.emitAudit(record)
+}
+
+// auditTransaction emits the record kept on the action response.
+func (c *Client) auditTransaction(actionResp *Response, trx *Transaction) {
+	if actionResp == nil || actionResp.audit == nil {
+		return
+	}
+
+	record := *actionResp.audit
+	actionResp.audit = nil
+
+	if trx != nil {
+		record.TransactionID = trx.ID
+	}
+
+	c.emitAudit(record)
+}
+
+func (c *Client) emitAudit(record AuditRecord) {
+	if err := c.auditSink.Audit(record); err != nil {
+		c.logger.Errorf("audit %s %s: %v", record.Method, record.Path, err)
+	}
+}
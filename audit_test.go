@@ -0,0 +1,86 @@
+package onappgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var records []AuditRecord
+	require.NoError(t, SetAuditSink(AuditSinkFunc(func(record AuditRecord) error {
+		records = append(records, record)
+		return nil
+	}))(client))
+
+	mux.HandleFunc("/virtual_machines/1/startup.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRequestID, "req-1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		created := time.Now().UTC().Format(time.RFC3339)
+		fmt.Fprintf(w, `[{"transaction":{"id":42,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":"%s"}}]`, created)
+	})
+	mux.HandleFunc("/users.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"errors":{"login":["is taken"]}}`)
+	})
+
+	trx, _, err := client.VirtualMachineActions.Startup(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 42, trx.ID)
+
+	_, _, err = client.Users.Create(ctx, &UserCreateRequest{Login: "jdoe", Password: "hunter2"})
+	require.Error(t, err)
+
+	require.Len(t, records, 2)
+
+	require.Equal(t, email, records[0].User)
+	require.Equal(t, http.MethodPost, records[0].Method)
+	require.Equal(t, "/virtual_machines/1/startup.json", records[0].Path)
+	require.Equal(t, http.StatusCreated, records[0].StatusCode)
+	require.Equal(t, "req-1", records[0].RequestID)
+	require.Equal(t, 42, records[0].TransactionID)
+	require.False(t, records[0].Time.IsZero())
+
+	require.Equal(t, http.StatusUnprocessableEntity, records[1].StatusCode)
+	require.JSONEq(t, `{"user":{"login":"jdoe","password":"[REDACTED]"}}`, string(records[1].Body))
+	require.NotEmpty(t, records[1].Error)
+	require.Zero(t, records[1].TransactionID)
+}
+
+func TestJSONLinesAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := OpenJSONLinesAuditFile(path)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Audit(AuditRecord{Method: http.MethodDelete, Path: "/settings/disks/5.json", StatusCode: 204}))
+	require.NoError(t, sink.Audit(AuditRecord{Method: http.MethodPost, Path: "/users.json", StatusCode: 201}))
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		lines = append(lines, record)
+	}
+
+	require.Len(t, lines, 2)
+	require.Equal(t, "/users.json", lines[1].Path)
+}
@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -41,6 +40,8 @@ type BackupsServiceOp struct {
 
 var _ BackupsService = &BackupsServiceOp{}
 
+var backupsCRUD = crud[Backup]{name: "Backup", path: deleteBackupsBasePath, root: "backup"}
+
 // Backup represent VirtualMachine backup
 type Backup struct {
 	AllowedHotMigrate        bool   `json:"allowed_hot_migrate,bool"`
@@ -93,43 +94,13 @@ type ConvertBackupToTemplateRequest struct {
 	MinMemorySize int    `json:"min_memory_size,omitempty"`
 }
 
-type backupCreateRequestRoot struct {
-	BackupCreateRequest *BackupCreateRequest `json:"backup"`
-}
-
-type backupRoot struct {
-	Backup *Backup `json:"backup"`
-}
-
 func (d BackupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Backups in the cloud
 func (s *BackupsServiceOp) List(ctx context.Context, vmID int, opt *ListOptions) ([]Backup, *Response, error) {
-	path := fmt.Sprintf(listOfAllVSBackupsBasePath, vmID) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Backup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Backup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup"]
-	}
-
-	return arr, resp, err
+	return backupsCRUD.in(listOfAllVSBackupsBasePath, vmID).list(ctx, s.client, opt)
 }
 
 // Get individual Backup
@@ -149,52 +120,12 @@ func (s *BackupsServiceOp) Create(ctx context.Context, createRequest *BackupCrea
 		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(createDiskBackupsBasePath, createRequest.DiskID) + apiFormat
-
-	rootRequest := &backupCreateRequestRoot{
-		BackupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("Backup [Create]  req: ", req)
-
-	root := new(backupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Backup, resp, err
+	return backupsCRUD.in(createDiskBackupsBasePath, createRequest.DiskID).create(ctx, s.client, createRequest)
 }
 
 // Delete Backup
 func (s *BackupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", deleteBackupsBasePath, id, apiFormat)
-
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Backup [Delete]  req: ", req)
-
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, err
+	return backupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // AllComputeResourceBackups - 
@@ -204,29 +135,7 @@ func (s *BackupsServiceOp) AllComputeResourceBackups(ctx context.Context, vmID i
 
 // ListOfDiskBackups -
 func (s *BackupsServiceOp) ListOfDiskBackups(ctx context.Context, vmID int, diskID int) ([]Backup, *Response, error) {
-	path := fmt.Sprintf(listOfDiskBackupsBasePath, vmID, diskID) + apiFormat
-	path, err := addOptions(path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Backup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Backup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup"]
-	}
-
-	return arr, resp, err
+	return backupsCRUD.in(listOfDiskBackupsBasePath, vmID, diskID).list(ctx, s.client, nil)
 }
 
 // BackupNote - Add/Edit Note of Backup
@@ -241,7 +150,7 @@ func (s *BackupsServiceOp) BackupNote(ctx context.Context, id int, noteRequest *
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Backup [BackupNote]  req: ", req)
+	s.client.debugRequest("Backup [BackupNote]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -258,7 +167,7 @@ func (s *BackupsServiceOp) ConvertBackupToTemplate(ctx context.Context, id int,
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Backup [ConvertBackupToTemplate]  req: ", req)
+	s.client.debugRequest("Backup [ConvertBackupToTemplate]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const backupResourcesBasePath string = "settings/backups/resources"
 // https://docs.onapp.com/apim/latest/backup-resources
 type BackupResourcesService interface {
 	List(context.Context, *ListOptions) ([]BackupResource, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]BackupResource, *Response, error)
 	Get(context.Context, int) (*BackupResource, *Response, error)
 	Create(context.Context, *BackupResourceCreateRequest) (*BackupResource, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type BackupResourcesServiceOp struct {
 
 var _ BackupResourcesService = &BackupResourcesServiceOp{}
 
+var backupResourcesCRUD = crud[BackupResource]{name: "BackupResource", path: backupResourcesBasePath, root: "backup_resource"}
+
 // BackupResource represents a BackupResource
 type BackupResource struct {
 	AdvancedOptions []AdvancedOptions `json:"advanced_options"`
@@ -65,110 +65,31 @@ type BackupResourceCreateRequest struct {
 	StartTime  string `json:"start_time,omitempty"`
 }
 
-type backupResourceCreateRequestRoot struct {
-	BackupResourceCreateRequest *BackupResourceCreateRequest `json:"backup_resource"`
-}
-
-type backupResourceRoot struct {
-	BackupResource *BackupResource `json:"backup_resource"`
-}
-
 func (d BackupResourceCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreGroups.
 func (s *BackupResourcesServiceOp) List(ctx context.Context, opt *ListOptions) ([]BackupResource, *Response, error) {
-	path := backupResourcesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]BackupResource
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]BackupResource, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup_resource"]
-	}
-
-	return arr, resp, err
+	return backupResourcesCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of BackupResources.
+func (s *BackupResourcesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]BackupResource, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual BackupResource.
 func (s *BackupResourcesServiceOp) Get(ctx context.Context, id int) (*BackupResource, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupResourcesBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(backupResourceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupResource, resp, err
+	return backupResourcesCRUD.get(ctx, s.client, id)
 }
 
 // Create BackupResource.
 func (s *BackupResourcesServiceOp) Create(ctx context.Context, createRequest *BackupResourceCreateRequest) (*BackupResource, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("BackupResource createRequest", "cannot be nil")
-	}
-
-	path := backupResourcesBasePath + apiFormat
-	rootRequest := &backupResourceCreateRequestRoot{
-		BackupResourceCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Println("BackupResource [Create] req: ", req)
-
-	root := new(backupResourceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupResource, resp, err
+	return backupResourcesCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete BackupResource.
 func (s *BackupResourcesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupResourcesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupResource [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupResourcesCRUD.delete(ctx, s.client, id, meta)
 }
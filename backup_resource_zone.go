@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const backupResourceZonesBasePath string = "settings/backups/resource_zones"
 // https://docs.onapp.com/apim/latest/backup-server-zones
 type BackupResourceZonesService interface {
 	List(context.Context, *ListOptions) ([]BackupResourceZone, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]BackupResourceZone, *Response, error)
 	Get(context.Context, int) (*BackupResourceZone, *Response, error)
 	Create(context.Context, *BackupResourceZoneCreateRequest) (*BackupResourceZone, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type BackupResourceZonesServiceOp struct {
 
 var _ BackupResourceZonesService = &BackupResourceZonesServiceOp{}
 
+var backupResourceZonesCRUD = crud[BackupResourceZone]{name: "BackupResourceZone", path: backupResourceZonesBasePath, root: "backup_resource_zone"}
+
 // BackupResourceZone represents a BackupResourceZone
 type BackupResourceZone struct {
 	ID              int    `json:"id,omitempty"`
@@ -45,111 +45,31 @@ type BackupResourceZoneCreateRequest struct {
 	LocationGroupID int    `json:"location_group_id,omitempty"`
 }
 
-type backupResourceZoneCreateRequestRoot struct {
-	BackupResourceZoneCreateRequest *BackupResourceZoneCreateRequest `json:"backup_resource_zone"`
-}
-
-type backupResourceZoneRoot struct {
-	BackupResourceZone *BackupResourceZone `json:"backup_resource_zone"`
-}
-
 func (d BackupResourceZoneCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreGroups.
 func (s *BackupResourceZonesServiceOp) List(ctx context.Context, opt *ListOptions) ([]BackupResourceZone, *Response, error) {
-	path := backupResourceZonesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]BackupResourceZone
-	resp, err := s.client.Do(ctx, req, &out)
-
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]BackupResourceZone, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup_resource_zone"]
-	}
+	return backupResourceZonesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of BackupResourceZones.
+func (s *BackupResourceZonesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]BackupResourceZone, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual BackupResourceZone.
 func (s *BackupResourceZonesServiceOp) Get(ctx context.Context, id int) (*BackupResourceZone, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupResourceZonesBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(backupResourceZoneRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupResourceZone, resp, err
+	return backupResourceZonesCRUD.get(ctx, s.client, id)
 }
 
 // Create BackupResourceZone.
 func (s *BackupResourceZonesServiceOp) Create(ctx context.Context, createRequest *BackupResourceZoneCreateRequest) (*BackupResourceZone, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("BackupResourceZone createRequest", "cannot be nil")
-	}
-
-	path := backupResourceZonesBasePath + apiFormat
-	rootRequest := &backupResourceZoneCreateRequestRoot{
-		BackupResourceZoneCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Println("BackupResourceZone [Create] req: ", req)
-
-	root := new(backupResourceZoneRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupResourceZone, resp, err
+	return backupResourceZonesCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete BackupResourceZone.
 func (s *BackupResourceZonesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupResourceZonesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupResourceZone [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupResourceZonesCRUD.delete(ctx, s.client, id, meta)
 }
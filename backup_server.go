@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -21,6 +20,7 @@ const backupServerIntegratedStorageSettingBasePath string = backupServersBasePat
 // See: https://docs.onapp.com/apim/latest/backup-servers
 type BackupServersService interface {
 	List(context.Context, *ListOptions) ([]BackupServer, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]BackupServer, *Response, error)
 	Get(context.Context, int) (*BackupServer, *Response, error)
 	Create(context.Context, *BackupServerCreateRequest) (*BackupServer, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -40,6 +40,8 @@ type BackupServersServiceOp struct {
 
 var _ BackupServersService = &BackupServersServiceOp{}
 
+var backupServersCRUD = crud[BackupServer]{name: "BackupServer", path: backupServersBasePath, root: "backup_server"}
+
 // BackupServer - represent a backup server of OnApp API
 type BackupServer struct {
 	BackupIPAddress     string `json:"backup_ip_address,omitempty"`
@@ -84,133 +86,38 @@ type BackupServerEditRequest struct {
 	IntegratedStorage bool   `json:"integrated_storage,bool"`
 }
 
-type backupServerRoot struct {
-	BackupServer *BackupServer `json:"backup_server"`
-}
-
-type backupServerCreateRequestRoot struct {
-	BackupServerCreateRequest *BackupServerCreateRequest `json:"backup_server"`
-}
-
 func (d BackupServerCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all BackupServers.
 func (s *BackupServersServiceOp) List(ctx context.Context, opt *ListOptions) ([]BackupServer, *Response, error) {
-	path := backupServersBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]BackupServer
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]BackupServer, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup_server"]
-	}
+	return backupServersCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of BackupServers.
+func (s *BackupServersServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]BackupServer, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual BackupServer.
 func (s *BackupServersServiceOp) Get(ctx context.Context, id int) (*BackupServer, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServersBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(backupServerRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupServer, resp, err
+	return backupServersCRUD.get(ctx, s.client, id)
 }
 
 // Create BackupServer.
 func (s *BackupServersServiceOp) Create(ctx context.Context, createRequest *BackupServerCreateRequest) (*BackupServer, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("BackupServer createRequest", "cannot be nil")
-	}
-
-	path := backupServersBasePath + apiFormat
-	rootRequest := &backupServerCreateRequestRoot{
-		BackupServerCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("BackupServer [Create] req: ", req)
-
-	root := new(backupServerRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupServer, resp, err
+	return backupServersCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete BackupServer.
 func (s *BackupServersServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServersBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupServer [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupServersCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit BackupServer.
 func (s *BackupServersServiceOp) Edit(ctx context.Context, id int, editRequest *BackupServerEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("BackupServer [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServersBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupServer [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupServersCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 
@@ -225,7 +132,7 @@ func (s *BackupServersServiceOp) Refresh(ctx context.Context, resID int) (*Hardw
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("BackupServer [Refresh] req: ", req)
+	s.client.debugRequest("BackupServer [Refresh]", req)
 
 	out := &rootHardware{}
 	resp, err := s.client.Do(ctx, req, out)
@@ -257,7 +164,7 @@ func (s *BackupServersServiceOp) Attach(ctx context.Context, resID int, attachRe
 	if err != nil {
 		return nil, err
 	}
-	log.Println("BackupServer [Attach] req: ", req)
+	s.client.debugRequest("BackupServer [Attach]", req)
 
 	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
@@ -283,7 +190,7 @@ func (s *BackupServersServiceOp) EditIntegratedStorageSettings(ctx context.Conte
 	if err != nil {
 		return nil, err
 	}
-	log.Println("BackupServer [EditIntegratedStorageSettings]  req: ", req)
+	s.client.debugRequest("BackupServer [EditIntegratedStorageSettings]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
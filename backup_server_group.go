@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const backupServerGroupsBasePath string = "settings/backup_server_zones"
 // https://docs.onapp.com/apim/latest/backup-resource-zones
 type BackupServerGroupsService interface {
 	List(context.Context, *ListOptions) ([]BackupServerGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]BackupServerGroup, *Response, error)
 	Get(context.Context, int) (*BackupServerGroup, *Response, error)
 	Create(context.Context, *BackupServerGroupCreateRequest) (*BackupServerGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type BackupServerGroupsServiceOp struct {
 
 var _ BackupServerGroupsService = &BackupServerGroupsServiceOp{}
 
+var backupServerGroupsCRUD = crud[BackupServerGroup]{name: "BackupServerGroup", path: backupServerGroupsBasePath, root: "backup_server_group"}
+
 // BackupServerGroup represents a BackupServerGroup
 type BackupServerGroup struct {
 	AdditionalFields  []AdditionalFields `json:"additional_fields,omitempty"`
@@ -63,131 +63,36 @@ type BackupServerGroupEditRequest struct {
 	LocationGroupID int    `json:"location_group_id,omitempty"`
 }
 
-type backupServerGroupCreateRequestRoot struct {
-	BackupServerGroupCreateRequest *BackupServerGroupCreateRequest `json:"backup_server_group"`
-}
-
-type backupServerGroupRoot struct {
-	BackupServerGroup *BackupServerGroup `json:"backup_server_group"`
-}
-
 func (d BackupServerGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreGroups.
 func (s *BackupServerGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]BackupServerGroup, *Response, error) {
-	path := backupServerGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]BackupServerGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]BackupServerGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup_server_group"]
-	}
-
-	return arr, resp, err
+	return backupServerGroupsCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of BackupServerGroups.
+func (s *BackupServerGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]BackupServerGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual BackupServerGroup.
 func (s *BackupServerGroupsServiceOp) Get(ctx context.Context, id int) (*BackupServerGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServerGroupsBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(backupServerGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupServerGroup, resp, err
+	return backupServerGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create BackupServerGroup.
 func (s *BackupServerGroupsServiceOp) Create(ctx context.Context, createRequest *BackupServerGroupCreateRequest) (*BackupServerGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("BackupServerGroup createRequest", "cannot be nil")
-	}
-
-	path := backupServerGroupsBasePath + apiFormat
-	rootRequest := &backupServerGroupCreateRequestRoot{
-		BackupServerGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Println("BackupServerGroup [Create] req: ", req)
-
-	root := new(backupServerGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupServerGroup, resp, err
+	return backupServerGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete BackupServerGroup.
 func (s *BackupServerGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServerGroupsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupServerGroup [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupServerGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit BackupServerGroup.
 func (s *BackupServerGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *BackupServerGroupEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("BackupServerGroup [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", backupServerGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("BackupServerGroup [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return backupServerGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
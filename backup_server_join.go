@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -29,6 +27,8 @@ type BackupServerJoinsServiceOp struct {
 
 var _ BackupServerJoinsService = &BackupServerJoinsServiceOp{}
 
+var backupServerJoinsCRUD = crud[BackupServerJoin]{name: "BackupServerJoin", root: "backup_server_join"}
+
 // BackupServerJoin represents a BackupServerJoin
 type BackupServerJoin struct {
 	ID             int    `json:"id,omitempty"`
@@ -57,135 +57,62 @@ type backupServerJoinCreateRequestRoot struct {
 	BackupServerID int `json:"backup_server_id,omitempty"`
 }
 
-type backupServerJoinRoot struct {
-	BackupServerJoin *BackupServerJoin `json:"backup_server_join"`
-}
-
 func (d BackupServerJoinCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all BackupServerJoins.
 func (s *BackupServerJoinsServiceOp) List(ctx context.Context, createRequest *BackupServerJoinCreateRequest, opt *ListOptions) ([]BackupServerJoin, *Response, error) {
-	path := ""
-	if val, ok := backupServerJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("BackupServerJoin List: wrong TargetJoinType", createRequest.TargetJoinType)
-	}
-
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
+	if createRequest == nil {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := backupServerJoinsCRUD.join(backupServerJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var out []map[string]BackupServerJoin
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]BackupServerJoin, len(out))
-	for i := range arr {
-		arr[i] = out[i]["backup_server_join"]
-	}
-
-	return arr, resp, err
+	return r.list(ctx, s.client, opt)
 }
 
 // Get individual BackupServerJoin.
 func (s *BackupServerJoinsServiceOp) Get(ctx context.Context, targetJoinType string, targetJoinID int, id int) (*BackupServerJoin, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := backupServerJoinPaths[targetJoinType]; ok {
-		path = fmt.Sprintf(val, targetJoinID)
-	} else {
-		return nil, nil, godo.NewArgError("BackupServerJoin Get: wrong TargetJoinType", targetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := backupServerJoinsCRUD.join(backupServerJoinPaths, targetJoinType, targetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	root := new(backupServerJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.BackupServerJoin, resp, err
+	return r.get(ctx, s.client, id)
 }
 
 // Create BackupServerJoin.
 func (s *BackupServerJoinsServiceOp) Create(ctx context.Context, createRequest *BackupServerJoinCreateRequest) (*BackupServerJoin, *Response, error) {
 	if createRequest == nil {
-		return nil, nil, godo.NewArgError("BackupServerJoin createRequest", "cannot be nil")
-	}
-
-	path := ""
-	if val, ok := backupServerJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("BackupServerJoin Create: wrong TargetJoinType", createRequest.TargetJoinType)
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	rootRequest := &backupServerJoinCreateRequestRoot{
-		BackupServerID: createRequest.BackupServerID,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
+	r, err := backupServerJoinsCRUD.join(backupServerJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("BackupServerJoin [Create] req: ", req)
 
-	root := new(backupServerJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+	rootRequest := &backupServerJoinCreateRequestRoot{
+		BackupServerID: createRequest.BackupServerID,
 	}
 
-	return root.BackupServerJoin, resp, err
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), rootRequest)
 }
 
 // Delete BackupServerJoin.
 func (s *BackupServerJoinsServiceOp) Delete(ctx context.Context, deleteRequest *BackupServerJoinDeleteRequest, meta interface{}) (*Response, error) {
 	if deleteRequest == nil {
-		return nil, godo.NewArgError("BackupServerJoin deleteRequest", "cannot be nil")
-	}
-
-	if deleteRequest.ID < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := backupServerJoinPaths[deleteRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, deleteRequest.TargetJoinID)
-	} else {
-		return nil, godo.NewArgError("BackupServerJoin Delete: wrong TargetJoinType", deleteRequest.TargetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, deleteRequest.ID, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
+		return nil, godo.NewArgError("deleteRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	r, err := backupServerJoinsCRUD.join(backupServerJoinPaths, deleteRequest.TargetJoinType, deleteRequest.TargetJoinID)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("BackupServerJoin [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.delete(ctx, s.client, deleteRequest.ID, meta)
 }
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const bucketsBasePath string = "billing/buckets"
 // See: https://docs.onapp.com/apim/latest/buckets
 type BucketsService interface {
 	List(context.Context, *ListOptions) ([]Bucket, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Bucket, *Response, error)
 	Get(context.Context, int) (*Bucket, *Response, error)
 	Create(context.Context, *BucketCreateRequest) (*Bucket, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type BucketsServiceOp struct {
 
 var _ BucketsService = &BucketsServiceOp{}
 
+var bucketsCRUD = crud[Bucket]{name: "Bucket", path: bucketsBasePath, root: "bucket"}
+
 // Bucket -
 type Bucket struct {
 	ID           int     `json:"id,omitempty"`
@@ -54,134 +54,38 @@ type BucketCreateRequest struct {
 	AllowsOwn    bool    `json:"allows_own,bool"`
 }
 
-type bucketCreateRequestRoot struct {
-	BucketCreateRequest *BucketCreateRequest `json:"bucket"`
-}
-
 type BucketEditRequest BucketCreateRequest
 
-type bucketRoot struct {
-	Bucket *Bucket `json:"bucket"`
-}
-
 func (d BucketCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Buckets.
 func (s *BucketsServiceOp) List(ctx context.Context, opt *ListOptions) ([]Bucket, *Response, error) {
-	path := bucketsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Bucket
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Bucket, len(out))
-	for i := range arr {
-		arr[i] = out[i]["bucket"]
-	}
-
-	return arr, resp, err
+	return bucketsCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of Buckets.
+func (s *BucketsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Bucket, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Bucket.
 func (s *BucketsServiceOp) Get(ctx context.Context, id int) (*Bucket, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", bucketsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(bucketRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Bucket, resp, err
+	return bucketsCRUD.get(ctx, s.client, id)
 }
 
 // Create Bucket.
 func (s *BucketsServiceOp) Create(ctx context.Context, createRequest *BucketCreateRequest) (*Bucket, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
-	}
-
-	path := bucketsBasePath + apiFormat
-
-	rootRequest := &bucketCreateRequestRoot{
-		BucketCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("Bucket [Create]  req: ", req)
-
-	root := new(bucketRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Bucket, resp, err
+	return bucketsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete Bucket.
 func (s *BucketsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", bucketsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Bucket [Delete]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return bucketsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Bucket.
 func (s *BucketsServiceOp) Edit(ctx context.Context, id int, editRequest *BucketEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("Bucket [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", bucketsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Bucket [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return bucketsCRUD.edit(ctx, s.client, id, editRequest)
 }
@@ -0,0 +1,306 @@
+package onappgo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// Names of the cacheable responses for SetCache.
+const (
+	// CacheImageTemplates caches ImageTemplates.List.
+	CacheImageTemplates = "image_templates"
+
+	// CacheRemoteTemplates caches RemoteTemplates.List.
+	CacheRemoteTemplates = "remote_templates"
+
+	// CacheHypervisorGroups caches HypervisorGroups.List.
+	CacheHypervisorGroups = "hypervisor_groups"
+
+	// CacheLocationGroups caches LocationGroups.List.
+	CacheLocationGroups = "location_groups"
+
+	// CacheConfiguration caches Configurations.Get.
+	CacheConfiguration = "configuration"
+)
+
+// cacheRoutes maps the templated paths of cacheable requests to cache names.
+var cacheRoutes = map[string]string{
+	imageTemplatesBasePath:   CacheImageTemplates,
+	remoteTemplatesBasePath:  CacheRemoteTemplates,
+	hypervisorGroupsBasePath: CacheHypervisorGroups,
+	locationGroupsBasePath:   CacheLocationGroups,
+	configurationBasePath:    CacheConfiguration,
+}
+
+// cacheInvalidations lists the caches invalidated by mutating requests below
+// the given templated paths.
+var cacheInvalidations = []struct {
+	route  string
+	caches []string
+}{
+	{imageTemplatesBasePath, []string{CacheImageTemplates, CacheRemoteTemplates}},
+	{imageTemplateGroupsBasePath, []string{CacheImageTemplates, CacheRemoteTemplates}},
+	{hypervisorGroupsBasePath, []string{CacheHypervisorGroups, CacheLocationGroups}},
+	{locationGroupsBasePath, []string{CacheLocationGroups, CacheHypervisorGroups}},
+	{configurationBasePath, []string{CacheConfiguration}},
+}
+
+// CacheStats are the counters of a single cache.
+type CacheStats struct {
+	Hits          int
+	Misses        int
+	Invalidations int
+}
+
+// SetCache is a client option for caching responses of slow-changing catalogs,
+// ttl is keyed by the Cache* names. Mutating requests sent through the client
+// invalidate the affected caches, e.g. ImageTemplateGroups.Attach invalidates
+// the template listings. Cached responses have Response.Attempts set to 0.
+func SetCache(ttl map[string]time.Duration) ClientOpt {
+	return func(c *Client) error {
+		known := make(map[string]bool, len(cacheRoutes))
+		for _, name := range cacheRoutes {
+			known[name] = true
+		}
+
+		for name, d := range ttl {
+			if !known[name] {
+				return godo.NewArgError("ttl", fmt.Sprintf("unknown cache %q", name))
+			}
+			if d <= 0 {
+				return godo.NewArgError("ttl", "must be positive")
+			}
+		}
+
+		c.cache = newResponseCache(ttl)
+		return nil
+	}
+}
+
+type skipCacheKey struct{}
+
+// WithoutCache returns a context for requests which bypass the cache, their
+// responses still refresh it.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+// CacheStats returns the counters of the caches enabled with SetCache.
+func (c *Client) CacheStats() map[string]CacheStats {
+	if c.cache == nil {
+		return nil
+	}
+
+	return c.cache.snapshot()
+}
+
+// InvalidateCache drops the cached responses of the given caches, or of all
+// caches when none is given.
+func (c *Client) InvalidateCache(names ...string) {
+	if c.cache == nil {
+		return
+	}
+
+	if len(names) == 0 {
+		for name := range c.cache.ttl {
+			names = append(names, name)
+		}
+	}
+
+	c.cache.invalidate(names)
+}
+
+// send returns the cached response of the request or sends it.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, int, error) {
+	if c.cache == nil {
+		return c.doWithRetry(ctx, req)
+	}
+
+	route := c.templatedPath(req)
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp, attempts, err := c.doWithRetry(ctx, req)
+		if err == nil {
+			c.cache.invalidate(invalidatedCaches(route))
+		}
+
+		return resp, attempts, err
+	}
+
+	name := cacheRoutes[route]
+	if _, ok := c.cache.ttl[name]; !ok || req.Method != http.MethodGet {
+		return c.doWithRetry(ctx, req)
+	}
+
+	key := req.URL.String()
+	if c.credentials != nil {
+		key = c.credentials.Username() + " " + key
+	}
+
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	e, generation, ok := c.cache.get(name, key, skip)
+	if ok {
+		return e.response(req), 0, nil
+	}
+
+	resp, attempts, err := c.doWithRetry(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, attempts, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, attempts, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	c.cache.put(name, key, generation, &cacheEntry{
+		status: resp.StatusCode,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+
+	return resp, attempts, nil
+}
+
+func invalidatedCaches(route string) []string {
+	var res []string
+	for _, inv := range cacheInvalidations {
+		if route == inv.route || strings.HasPrefix(route, inv.route+"/") {
+			res = append(res, inv.caches...)
+		}
+	}
+
+	// settings.json is the endpoint of Configurations.Edit
+	if route == configurationEditBasePath {
+		res = append(res, CacheConfiguration)
+	}
+
+	return res
+}
+
+type cacheEntry struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+type responseCache struct {
+	ttl map[string]time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]map[string]*cacheEntry
+	stats   map[string]*CacheStats
+
+	// generations are bumped on invalidation, so that responses of requests
+	// sent before a mutation are not stored after it
+	generations map[string]int
+}
+
+func newResponseCache(ttl map[string]time.Duration) *responseCache {
+	rc := &responseCache{
+		ttl:         make(map[string]time.Duration, len(ttl)),
+		now:         time.Now,
+		entries:     make(map[string]map[string]*cacheEntry, len(ttl)),
+		stats:       make(map[string]*CacheStats, len(ttl)),
+		generations: make(map[string]int, len(ttl)),
+	}
+
+	for name, d := range ttl {
+		rc.ttl[name] = d
+		rc.entries[name] = make(map[string]*cacheEntry)
+		rc.stats[name] = &CacheStats{}
+	}
+
+	return rc
+}
+
+// get returns the fresh cached entry and the current generation of the cache,
+// bypass skips the lookup without counting a miss.
+func (rc *responseCache) get(name, key string, bypass bool) (*cacheEntry, int, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	generation := rc.generations[name]
+	if bypass {
+		return nil, generation, false
+	}
+
+	e, ok := rc.entries[name][key]
+	if ok && rc.now().After(e.expires) {
+		delete(rc.entries[name], key)
+		ok = false
+	}
+
+	if ok {
+		rc.stats[name].Hits++
+	} else {
+		rc.stats[name].Misses++
+	}
+
+	return e, generation, ok
+}
+
+func (rc *responseCache) put(name, key string, generation int, e *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.generations[name] != generation {
+		return
+	}
+
+	e.expires = rc.now().Add(rc.ttl[name])
+	rc.entries[name][key] = e
+}
+
+func (rc *responseCache) invalidate(names []string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, name := range names {
+		if _, ok := rc.ttl[name]; !ok {
+			continue
+		}
+
+		rc.entries[name] = make(map[string]*cacheEntry)
+		rc.generations[name]++
+		rc.stats[name].Invalidations++
+	}
+}
+
+func (rc *responseCache) snapshot() map[string]CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	res := make(map[string]CacheStats, len(rc.stats))
+	for name, s := range rc.stats {
+		res[name] = *s
+	}
+
+	return res
+}
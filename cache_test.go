@@ -0,0 +1,97 @@
+package onappgo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_readThroughAndInvalidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetCache(map[string]time.Duration{
+		CacheImageTemplates: time.Minute,
+		CacheConfiguration:  time.Minute,
+	})(client))
+
+	calls := 0
+	mux.HandleFunc("/templates.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `[{"image_template":{"id":%d}}]`, calls)
+	})
+	mux.HandleFunc("/settings/image_template_groups/1/relation_group_templates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"image_template_group":{"id":1}}`)
+	})
+
+	templates, resp, err := client.ImageTemplates.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, templates[0].ID)
+	require.Equal(t, 1, resp.Attempts)
+
+	templates, resp, err = client.ImageTemplates.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, templates[0].ID)
+	require.Equal(t, 0, resp.Attempts)
+	require.Equal(t, 1, calls)
+
+	// other pages are cached separately
+	_, _, err = client.ImageTemplates.List(ctx, &ListOptions{Page: 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	templates, _, err = client.ImageTemplates.List(WithoutCache(ctx), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, templates[0].ID)
+
+	templates, _, err = client.ImageTemplates.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, templates[0].ID)
+
+	_, _, err = client.ImageTemplateGroups.Attach(ctx, 1, &ImageTemplateGroupAttachRequest{TemplateID: 3})
+	require.NoError(t, err)
+
+	templates, _, err = client.ImageTemplates.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, templates[0].ID)
+
+	require.Equal(t, CacheStats{Hits: 2, Misses: 3, Invalidations: 1}, client.CacheStats()[CacheImageTemplates])
+	require.Equal(t, CacheStats{}, client.CacheStats()[CacheConfiguration])
+}
+
+func TestCache_expiry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetCache(map[string]time.Duration{CacheHypervisorGroups: time.Minute})(client))
+
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+
+	calls := 0
+	mux.HandleFunc("/settings/hypervisor_zones.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[]`)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.HypervisorGroups.List(ctx, nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, calls)
+
+	now = now.Add(2 * time.Minute)
+
+	_, _, err := client.HypervisorGroups.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestSetCache_unknown(t *testing.T) {
+	_, err := New(nil, SetCache(map[string]time.Duration{"virtual_machines": time.Minute}))
+	require.Error(t, err)
+}
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -18,6 +15,7 @@ const cloudBootAvailableResourcesBasePath string = "settings/assets"
 // See: https://docs.onapp.com/apim/latest/compute-resources
 type CloudbootComputeResourcesService interface {
 	List(context.Context, *ListOptions) ([]CloudbootComputeResource, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]CloudbootComputeResource, *Response, error)
 	Get(context.Context, int) (*CloudbootComputeResource, *Response, error)
 	Create(context.Context, *CloudbootComputeResourceCreateRequest) (*CloudbootComputeResource, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -34,6 +32,10 @@ type CloudbootComputeResourcesServiceOp struct {
 
 var _ CloudbootComputeResourcesService = &CloudbootComputeResourcesServiceOp{}
 
+var cloudbootComputeResourcesCRUD = crud[CloudbootComputeResource]{name: "CloudbootComputeResource", path: hypervisorsBasePath, root: "hypervisor"}
+
+var cloudbootAssetsCRUD = crud[Asset]{name: "CloudbootComputeResource", path: cloudBootAvailableResourcesBasePath, root: "asset"}
+
 type CloudbootComputeResource Hypervisor
 
 type Asset struct {
@@ -98,154 +100,45 @@ type CloudbootComputeResourceEditRequest struct {
 	ApplyHypervisorGroupCustomConfig bool     `json:"apply_hypervisor_group_custom_config,bool"`
 }
 
-type cloudbootComputeResourceCreateRequestRoot struct {
-	CloudbootComputeResourceCreateRequest *CloudbootComputeResourceCreateRequest `json:"hypervisor"`
-}
-
-type cloudbootComputeResourceRoot struct {
-	CloudbootComputeResource *CloudbootComputeResource `json:"hypervisor"`
-}
-
 func (d CloudbootComputeResourceCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Cloudboot CloudbootComputeResources
 func (s *CloudbootComputeResourcesServiceOp) List(ctx context.Context, opt *ListOptions) ([]CloudbootComputeResource, *Response, error) {
-	path := hypervisorsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]CloudbootComputeResource
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]CloudbootComputeResource, len(out))
-	for i := range arr {
-		arr[i] = out[i]["hypervisor"]
-	}
+	return cloudbootComputeResourcesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of CloudbootComputeResources.
+func (s *CloudbootComputeResourcesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]CloudbootComputeResource, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Cloudboot CloudbootComputeResource
 func (s *CloudbootComputeResourcesServiceOp) Get(ctx context.Context, id int) (*CloudbootComputeResource, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(cloudbootComputeResourceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.CloudbootComputeResource, resp, err
+	return cloudbootComputeResourcesCRUD.get(ctx, s.client, id)
 }
 
 // Create Cloudboot CloudbootComputeResource
 func (s *CloudbootComputeResourcesServiceOp) Create(ctx context.Context, createRequest *CloudbootComputeResourceCreateRequest) (*CloudbootComputeResource, *Response, error) {
 	if createRequest == nil {
-		return nil, nil, godo.NewArgError("CloudbootComputeResource createRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(cloudBootComputeResourcesBasePath, createRequest.Mac) + apiFormat
-	rootRequest := &cloudbootComputeResourceCreateRequestRoot{
-		CloudbootComputeResourceCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("CloudbootComputeResource [Create] req: ", req)
-
-	root := new(cloudbootComputeResourceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	return root.CloudbootComputeResource, resp, err
+	return cloudbootComputeResourcesCRUD.in(cloudBootComputeResourcesBasePath, createRequest.Mac).create(ctx, s.client, createRequest)
 }
 
 // Delete Cloudboot CloudbootComputeResource
 func (s *CloudbootComputeResourcesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("CloudbootComputeResource [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return cloudbootComputeResourcesCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Cloudboot CloudbootComputeResource
 func (s *CloudbootComputeResourcesServiceOp) Edit(ctx context.Context, id int, editRequest *CloudbootComputeResourceEditRequest) (*Response, error) {
-	if editRequest == nil || id < 1 {
-		return nil, godo.NewArgError("editRequest || id", "cannot be nil or less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("CloudbootComputeResource [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return cloudbootComputeResourcesCRUD.edit(ctx, s.client, id, editRequest)
 }
 
-
 // CloudbootAvailableResources - List all Cloudboot available resources
 func (s *CloudbootComputeResourcesServiceOp) CloudbootAvailableResources(ctx context.Context) ([]Asset, *Response, error) {
-	path := cloudBootAvailableResourcesBasePath + apiFormat
-	path, err := addOptions(path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Asset
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Asset, len(out))
-	for i := range arr {
-		arr[i] = out[i]["asset"]
-	}
-
-	return arr, resp, err
+	return cloudbootAssetsCRUD.list(ctx, s.client, nil)
 }
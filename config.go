@@ -0,0 +1,321 @@
+package onappgo
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by LoadConfig and NewFromEnvironment.
+const (
+	EnvConfigFile         = "ONAPP_CONFIG_FILE"
+	EnvProfile            = "ONAPP_PROFILE"
+	EnvBaseURL            = "ONAPP_URL"
+	EnvEmail              = "ONAPP_EMAIL"
+	EnvAPIKey             = "ONAPP_API_KEY"
+	EnvAllowUnverifiedSSL = "ONAPP_ALLOW_UNVERIFIED_SSL"
+)
+
+// Names of the settings in profiles and in Config.Sources.
+const (
+	SettingBaseURL            = "url"
+	SettingEmail              = "email"
+	SettingAPIKey             = "api_key"
+	SettingAllowUnverifiedSSL = "allow_unverified_ssl"
+)
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// SourceDefault is the source of settings which are not set anywhere.
+const SourceDefault = "default"
+
+var settingEnv = map[string]string{
+	SettingBaseURL:            EnvBaseURL,
+	SettingEmail:              EnvEmail,
+	SettingAPIKey:             EnvAPIKey,
+	SettingAllowUnverifiedSSL: EnvAllowUnverifiedSSL,
+}
+
+// ErrProfileNotFound is returned by LoadConfig for a selected profile which is
+// not in the configuration file.
+var ErrProfileNotFound = errors.New("onappgo: profile not found")
+
+// Config is the client configuration of a single cloud, see LoadConfig.
+type Config struct {
+	Profile            string
+	BaseURL            string
+	Email              string
+	APIKey             string
+	AllowUnverifiedSSL bool
+
+	// Sources tells where each setting came from, keyed by the Setting*
+	// names, e.g. "env ONAPP_URL" or "/home/me/.onapp/config [staging]".
+	Sources map[string]string
+}
+
+// DefaultConfigFile returns the path of ~/.onapp/config.
+func DefaultConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".onapp", "config")
+}
+
+// LoadConfig reads the profile from the configuration file and applies the
+// ONAPP_* environment overrides on top of it.
+//
+// An empty path means ONAPP_CONFIG_FILE or DefaultConfigFile, the default file
+// may be missing. An empty profile means ONAPP_PROFILE or DefaultProfile.
+// Files with the .yaml or .yml extension are YAML with the profiles under the
+// "clouds" key, other files are INI with one section per profile:
+//
+//	[staging]
+//	url = https://cp.staging.example.com
+//	email = admin@example.com
+//	api_key = 0123456789abcdef
+//	allow_unverified_ssl = true
+func LoadConfig(path, profile string) (*Config, error) {
+	explicitPath := path != ""
+	if !explicitPath {
+		path = os.Getenv(EnvConfigFile)
+		explicitPath = path != ""
+	}
+	if !explicitPath {
+		path = DefaultConfigFile()
+	}
+
+	explicitProfile := profile != ""
+	if !explicitProfile {
+		profile = os.Getenv(EnvProfile)
+		explicitProfile = profile != ""
+	}
+	if !explicitProfile {
+		profile = DefaultProfile
+	}
+
+	values := make(map[string]string)
+	sources := make(map[string]string)
+
+	profiles, err := readProfiles(path)
+	switch {
+	case err == nil:
+		settings, ok := profiles[profile]
+		if !ok && explicitProfile {
+			return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, profile, path)
+		}
+
+		for k, v := range settings {
+			if _, known := settingEnv[k]; !known {
+				return nil, fmt.Errorf("onappgo: unknown setting %q in %s [%s]", k, path, profile)
+			}
+
+			values[k] = v
+			sources[k] = fmt.Sprintf("%s [%s]", path, profile)
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicitPath:
+		if explicitProfile {
+			return nil, fmt.Errorf("%w: %q, no configuration file %s", ErrProfileNotFound, profile, path)
+		}
+	default:
+		return nil, err
+	}
+
+	for k, env := range settingEnv {
+		if v, ok := os.LookupEnv(env); ok {
+			values[k] = v
+			sources[k] = "env " + env
+		}
+	}
+
+	for k := range settingEnv {
+		if _, ok := sources[k]; !ok {
+			sources[k] = SourceDefault
+		}
+	}
+
+	cfg := &Config{
+		Profile: profile,
+		BaseURL: values[SettingBaseURL],
+		Email:   values[SettingEmail],
+		APIKey:  values[SettingAPIKey],
+		Sources: sources,
+	}
+
+	if v, ok := values[SettingAllowUnverifiedSSL]; ok && v != "" {
+		cfg.AllowUnverifiedSSL, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("onappgo: invalid %s %q from %s", SettingAllowUnverifiedSSL, v, sources[SettingAllowUnverifiedSSL])
+		}
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the base URL is an absolute http(s) URL and that email
+// and API key are set together.
+func (cfg *Config) Validate() error {
+	if cfg.BaseURL == "" {
+		return fmt.Errorf("onappgo: %s is not set, use %s or the %q profile setting", SettingBaseURL, EnvBaseURL, SettingBaseURL)
+	}
+
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return fmt.Errorf("onappgo: invalid %s from %s: %w", SettingBaseURL, cfg.Sources[SettingBaseURL], err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("onappgo: invalid %s %q from %s: must be an absolute http or https URL",
+			SettingBaseURL, cfg.BaseURL, cfg.Sources[SettingBaseURL])
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("onappgo: invalid %s %q from %s: must not have a query or fragment",
+			SettingBaseURL, cfg.BaseURL, cfg.Sources[SettingBaseURL])
+	}
+
+	if (cfg.Email == "") != (cfg.APIKey == "") {
+		return fmt.Errorf("onappgo: %s (from %s) and %s (from %s) must be set together",
+			SettingEmail, cfg.Sources[SettingEmail], SettingAPIKey, cfg.Sources[SettingAPIKey])
+	}
+
+	return nil
+}
+
+// ClientOpts validates the configuration and returns the client options
+// applying it.
+func (cfg *Config) ClientOpts() ([]ClientOpt, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := []ClientOpt{SetBaseURL(cfg.BaseURL)}
+
+	// The transport is only configured when asked for, so clients with a
+	// custom RoundTripper keep working
+	if cfg.AllowUnverifiedSSL {
+		opts = append(opts, SetAllowUnverifiedSSL(true))
+	}
+
+	if cfg.Email != "" {
+		opts = append(opts, SetCredentials(&APIKeyCredentials{Email: cfg.Email, APIKey: cfg.APIKey}))
+	}
+
+	return opts, nil
+}
+
+// String describes the settings with their sources, the API key is redacted.
+func (cfg *Config) String() string {
+	apiKey := ""
+	if cfg.APIKey != "" {
+		apiKey = redacted
+	}
+
+	values := map[string]string{
+		SettingBaseURL:            cfg.BaseURL,
+		SettingEmail:              cfg.Email,
+		SettingAPIKey:             apiKey,
+		SettingAllowUnverifiedSSL: strconv.FormatBool(cfg.AllowUnverifiedSSL),
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "profile %s:", cfg.Profile)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%q (%s)", k, values[k], cfg.Sources[k])
+	}
+
+	return b.String()
+}
+
+// NewFromEnvironment returns a client configured by LoadConfig with the
+// profile selected by ONAPP_PROFILE, opts are applied after the configuration.
+func NewFromEnvironment(httpClient *http.Client, opts ...ClientOpt) (*Client, error) {
+	cfg, err := LoadConfig("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	cfgOpts, err := cfg.ClientOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(httpClient, append(cfgOpts, opts...)...)
+}
+
+// readProfiles returns the settings of all profiles in the file.
+func readProfiles(path string) (map[string]map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseYAMLProfiles(path, data)
+	}
+
+	return parseINIProfiles(path, data)
+}
+
+func parseYAMLProfiles(path string, data []byte) (map[string]map[string]string, error) {
+	var doc struct {
+		Clouds map[string]map[string]string `yaml:"clouds"`
+	}
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("onappgo: invalid configuration file %s: %w", path, err)
+	}
+
+	return doc.Clouds, nil
+}
+
+func parseINIProfiles(path string, data []byte) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+
+	var current map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = make(map[string]string)
+			}
+			current = profiles[name]
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || current == nil {
+			return nil, fmt.Errorf("onappgo: invalid configuration file %s: line %d: expected key = value in a [profile]", path, n)
+		}
+
+		current[strings.TrimSpace(kv[0])] = strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+	}
+
+	return profiles, scanner.Err()
+}
@@ -0,0 +1,152 @@
+package onappgo
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testINIConfig = `
+# clouds of the team
+[default]
+url = https://cp.example.com
+email = admin@example.com
+api_key = 0123456789
+
+[staging]
+url = "https://cp.staging.example.com"
+email = ops@example.com
+api_key = abcdef
+allow_unverified_ssl = true
+`
+
+const testYAMLConfig = `
+clouds:
+  staging:
+    url: https://cp.staging.example.com
+    email: ops@example.com
+    api_key: abcdef
+    allow_unverified_ssl: true
+`
+
+// clearConfigEnv unsets the ONAPP_* variables for the test.
+func clearConfigEnv(t *testing.T) {
+	for _, env := range []string{EnvConfigFile, EnvProfile, EnvBaseURL, EnvEmail, EnvAPIKey, EnvAllowUnverifiedSSL} {
+		if v, ok := os.LookupEnv(env); ok {
+			os.Unsetenv(env)
+			t.Cleanup(func() { os.Setenv(env, v) })
+		}
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadConfig_INI(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config", testINIConfig)
+
+	cfg, err := LoadConfig(path, "staging")
+	require.NoError(t, err)
+	require.Equal(t, "https://cp.staging.example.com", cfg.BaseURL)
+	require.Equal(t, "ops@example.com", cfg.Email)
+	require.Equal(t, "abcdef", cfg.APIKey)
+	require.True(t, cfg.AllowUnverifiedSSL)
+	require.Equal(t, path+" [staging]", cfg.Sources[SettingBaseURL])
+
+	cfg, err = LoadConfig(path, "")
+	require.NoError(t, err)
+	require.Equal(t, DefaultProfile, cfg.Profile)
+	require.Equal(t, SourceDefault, cfg.Sources[SettingAllowUnverifiedSSL])
+	require.NotContains(t, cfg.String(), "0123456789")
+
+	_, err = LoadConfig(path, "production")
+	require.True(t, errors.Is(err, ErrProfileNotFound))
+}
+
+func TestLoadConfig_YAMLWithEnvironment(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "clouds.yaml", testYAMLConfig)
+
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvProfile, "staging")
+	t.Setenv(EnvAPIKey, "from-env")
+
+	cfg, err := LoadConfig("", "")
+	require.NoError(t, err)
+	require.Equal(t, "staging", cfg.Profile)
+	require.Equal(t, "ops@example.com", cfg.Email)
+	require.Equal(t, "from-env", cfg.APIKey)
+	require.True(t, cfg.AllowUnverifiedSSL)
+	require.Equal(t, "env "+EnvAPIKey, cfg.Sources[SettingAPIKey])
+	require.Equal(t, path+" [staging]", cfg.Sources[SettingEmail])
+
+	c, err := NewFromEnvironment(nil)
+	require.NoError(t, err)
+	require.Equal(t, "cp.staging.example.com", c.BaseURL.Host)
+	require.Equal(t, "ops@example.com", c.credentials.Username())
+}
+
+type testRoundTripper struct{}
+
+func (testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewFromEnvironment_customTransport(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvBaseURL, "https://cp.example.com")
+
+	hc := &http.Client{Transport: testRoundTripper{}}
+	c, err := NewFromEnvironment(hc)
+	require.NoError(t, err)
+	require.Equal(t, testRoundTripper{}, hc.Transport)
+	require.Equal(t, "cp.example.com", c.BaseURL.Host)
+
+	t.Setenv(EnvAllowUnverifiedSSL, "true")
+	_, err = NewFromEnvironment(hc)
+	require.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing"))
+
+	_, err := LoadConfig("", "")
+	require.Error(t, err)
+
+	cases := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{"valid", Config{BaseURL: "https://cp.example.com/", Email: "a@example.com", APIKey: "k"}, true},
+		{"no credentials", Config{BaseURL: "http://cp.example.com"}, true},
+		{"missing URL", Config{}, false},
+		{"relative URL", Config{BaseURL: "cp.example.com"}, false},
+		{"unsupported scheme", Config{BaseURL: "ftp://cp.example.com"}, false},
+		{"query", Config{BaseURL: "https://cp.example.com?x=1"}, false},
+		{"email without API key", Config{BaseURL: "https://cp.example.com", Email: "a@example.com"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
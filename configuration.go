@@ -2,7 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"log"
 	"net/http"
 )
 
@@ -26,6 +25,8 @@ type ConfigurationsServiceOp struct {
 
 var _ ConfigurationsService = &ConfigurationsServiceOp{}
 
+var configurationsCRUD = crud[Configuration]{name: "Configuration", path: configurationBasePath, root: "settings"}
+
 // Configuration - represent configuration settings of OnApp installation.
 type Configuration struct {
 	ActionGlobalLockExpirationTimeout     int      `json:"action_global_lock_expiration_timeout,omitempty"`
@@ -255,26 +256,9 @@ type configurationEditRequestRoot struct {
 	Configuration *map[string]interface{} `json:"configuration"`
 }
 
-type configurationRoot struct {
-	Configuration *Configuration `json:"settings"`
-}
-
 // Get individual Configuration.
 func (s *ConfigurationsServiceOp) Get(ctx context.Context) (*Configuration, *Response, error) {
-	path := configurationBasePath + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(configurationRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Configuration, resp, err
+	return configurationsCRUD.do(ctx, s.client, "Get", http.MethodGet, configurationsCRUD.collection(), nil)
 }
 
 // RestartConfigurationRequest -
@@ -301,11 +285,5 @@ func (s *ConfigurationsServiceOp) Edit(ctx context.Context, editRequest *map[str
 		Configuration: editRequest,
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, rootRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("[DEBUG] Configuration [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return configurationsCRUD.send(ctx, s.client, "Edit", http.MethodPut, path, rootRequest)
 }
@@ -0,0 +1,94 @@
+package onappgo
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// ConnectionPool configures the reuse of connections to the control panel,
+// zero values keep the defaults of the transport. Connections are kept alive
+// unless DisableKeepAlives is set.
+type ConnectionPool struct {
+	// MaxIdleConns caps the idle connections across all hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps the idle connections to the control panel,
+	// net/http keeps only 2 by default.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost caps all connections to the control panel.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout closes idle connections, it should be shorter than the
+	// keep-alive timeout of the control panel web server.
+	IdleConnTimeout time.Duration
+
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool
+}
+
+// SetConnectionPool is a client option for the connection reuse limits.
+func SetConnectionPool(pool ConnectionPool) ClientOpt {
+	return func(c *Client) error {
+		if pool.MaxIdleConns < 0 || pool.MaxIdleConnsPerHost < 0 || pool.MaxConnsPerHost < 0 || pool.IdleConnTimeout < 0 {
+			return godo.NewArgError("pool", "limits cannot be negative")
+		}
+
+		return c.configureTransport(func(t *http.Transport) error {
+			if pool.MaxIdleConns > 0 {
+				t.MaxIdleConns = pool.MaxIdleConns
+			}
+			if pool.MaxIdleConnsPerHost > 0 {
+				t.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+			}
+			if pool.MaxConnsPerHost > 0 {
+				t.MaxConnsPerHost = pool.MaxConnsPerHost
+			}
+			if pool.IdleConnTimeout > 0 {
+				t.IdleConnTimeout = pool.IdleConnTimeout
+			}
+			t.DisableKeepAlives = pool.DisableKeepAlives
+
+			return nil
+		})
+	}
+}
+
+// httpTransport returns the transport the HTTP client sends requests with,
+// nil if it is not an *http.Transport.
+func (c *Client) httpTransport() *http.Transport {
+	rt := c.client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	t, _ := rt.(*http.Transport)
+	return t
+}
+
+// configureTransport applies fn to the client transport. The transport of a
+// caller-supplied HTTP client is cloned and the HTTP client copied, so that
+// neither is modified.
+func (c *Client) configureTransport(fn func(*http.Transport) error) error {
+	if c.client.Transport != c.transport {
+		t := c.transport
+		if c.client.Transport != nil {
+			ht, ok := c.client.Transport.(*http.Transport)
+			if !ok {
+				return fmt.Errorf("onappgo: transport options need an *http.Transport, the HTTP client uses %T", c.client.Transport)
+			}
+
+			t = ht.Clone()
+		}
+
+		hc := *c.client
+		hc.Transport = t
+		c.client = &hc
+		c.transport = t
+	}
+
+	return fn(c.transport)
+}
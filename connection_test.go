@@ -0,0 +1,185 @@
+package onappgo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_keepAlive(t *testing.T) {
+	var mu sync.Mutex
+	addrs := make(map[string]bool)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		addrs[r.RemoteAddr] = true
+		mu.Unlock()
+
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(nil, SetBaseURL(srv.URL), SetConnectionPool(ConnectionPool{MaxIdleConnsPerHost: 4}))
+	require.NoError(t, err)
+	require.Equal(t, 4, c.transport.MaxIdleConnsPerHost)
+
+	for i := 0; i < 5; i++ {
+		req, err := c.NewRequest(ctx, http.MethodPost, "virtual_machines.json", map[string]int{"i": i})
+		require.NoError(t, err)
+		require.False(t, req.Close)
+
+		_, err = c.Do(ctx, req, nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, addrs, 1)
+}
+
+// testRawServer serves HTTP/1.1 requests on kept alive connections. Before
+// every request drop is called with the number of the request on the
+// connection and the request itself, nil if it was not read yet. A true
+// result resets the connection without a response.
+func testRawServer(t *testing.T, drop func(n int, req *http.Request) bool) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	reset := func(conn net.Conn) {
+		conn.(*net.TCPConn).SetLinger(0)
+		conn.Close()
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				for n := 1; ; n++ {
+					// wait for the request to arrive without reading it
+					if _, err := br.Peek(1); err != nil {
+						return
+					}
+					if drop(n, nil) {
+						reset(conn)
+						return
+					}
+
+					req, err := http.ReadRequest(br)
+					if err != nil {
+						return
+					}
+					_, _ = io.Copy(ioutil.Discard, req.Body)
+
+					if drop(n, req) {
+						reset(conn)
+						return
+					}
+					fmt.Fprint(conn, "HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\n{}")
+				}
+			}()
+		}
+	}()
+
+	return "http://" + l.Addr().String()
+}
+
+func TestClient_staleConnectionRetry(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+
+	// the second request on every connection finds it closed by the server
+	// before the request is read
+	url := testRawServer(t, func(n int, req *http.Request) bool {
+		if req == nil {
+			return n == 2
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		mu.Lock()
+		bodies = append(bodies, req.Method+" "+req.URL.Path+string(body))
+		mu.Unlock()
+		return false
+	})
+
+	c, err := New(nil, SetBaseURL(url))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("virtual_machines/%d.json", i), nil)
+		resp, err := c.Do(ctx, req, nil)
+		require.NoError(t, err)
+		require.Equal(t, i+1, resp.Attempts)
+	}
+
+	require.Equal(t, []string{"DELETE /virtual_machines/0.json", "DELETE /virtual_machines/1.json"}, bodies)
+}
+
+func TestClient_staleConnectionNoRetryOfHandledPost(t *testing.T) {
+	var mu sync.Mutex
+	posts := 0
+
+	// the second request on every connection is handled, then the server
+	// drops the connection without a response
+	url := testRawServer(t, func(n int, req *http.Request) bool {
+		if req == nil {
+			return false
+		}
+
+		mu.Lock()
+		posts++
+		mu.Unlock()
+		return n == 2
+	})
+
+	c, err := New(nil, SetBaseURL(url))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest(ctx, http.MethodPost, "virtual_machines.json", map[string]int{"i": i})
+		_, err = c.Do(ctx, req, nil)
+	}
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2, posts)
+}
+
+func benchmarkClient(b *testing.B, pool ConnectionPool) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"virtual_machine":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.Client(), SetBaseURL(srv.URL), SetConnectionPool(pool))
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := c.VirtualMachines.Get(ctx, 1)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClient_keepAlive(b *testing.B) {
+	benchmarkClient(b, ConnectionPool{})
+}
+
+func BenchmarkClient_newConnectionPerRequest(b *testing.B) {
+	benchmarkClient(b, ConnectionPool{DisableKeepAlives: true})
+}
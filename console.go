@@ -0,0 +1,323 @@
+package onappgo
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"golang.org/x/net/websocket"
+)
+
+const consoleBasePath = "virtual_machines/%d/console"
+
+// ConsoleService is an interface for interfacing with the VirtualMachine
+// console endpoints of the OnApp API
+// See: https://docs.onapp.com/apim/latest/virtual-servers
+type ConsoleService interface {
+	Open(context.Context, int) (*ConsoleSession, *Response, error)
+	Tunnel(string) ConsoleDialer
+}
+
+// ConsoleServiceOp handles communication with the console related methods of
+// the OnApp API.
+type ConsoleServiceOp struct {
+	client *Client
+}
+
+var _ ConsoleService = &ConsoleServiceOp{}
+
+var consoleCRUD = crud[remoteAccessSession]{name: "Console", path: consoleBasePath, root: "remote_access_session"}
+
+// remoteAccessSession is the session the API opens for the console
+type remoteAccessSession struct {
+	ID               int    `json:"id,omitempty"`
+	Port             int    `json:"port,omitempty"`
+	RemoteKey        string `json:"remote_key,omitempty"`
+	VirtualMachineID int    `json:"virtual_machine_id,omitempty"`
+	CreatedAt        string `json:"created_at,omitempty"`
+	ExpireAt         string `json:"expire_at,omitempty"`
+}
+
+// ConsoleSession represents an open VNC console of a VirtualMachine
+type ConsoleSession struct {
+	ID               int
+	VirtualMachineID int
+
+	// Host and Port of the VNC server on the hypervisor
+	Host string
+	Port int
+
+	// Password of the VNC server
+	Password string
+
+	// RemoteKey identifies the session in the control panel
+	RemoteKey string
+
+	// ExpiresAt is zero if the control panel does not report the expiry
+	ExpiresAt time.Time
+}
+
+// Address returns the host:port of the VNC server.
+func (s *ConsoleSession) Address() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// Expired reports whether the session has expired.
+func (s *ConsoleSession) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
+
+func (s ConsoleSession) String() string {
+	return godo.Stringify(s)
+}
+
+// Open a console session of the VirtualMachine.
+func (s *ConsoleServiceOp) Open(ctx context.Context, id int) (*ConsoleSession, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	r := consoleCRUD.at(id)
+	remote, resp, err := r.do(ctx, s.client, "Open", http.MethodGet, r.collection(), nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// The address and password of the VNC server are only reported with the
+	// VirtualMachine
+	vm, resp, err := s.client.VirtualMachines.Get(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	session := &ConsoleSession{
+		ID:               remote.ID,
+		VirtualMachineID: id,
+		Host:             vm.LocalRemoteAccessIPAddress,
+		Port:             remote.Port,
+		Password:         vm.RemoteAccessPassword,
+		RemoteKey:        remote.RemoteKey,
+	}
+
+	if session.Port == 0 {
+		session.Port = vm.LocalRemoteAccessPort
+	}
+
+	if expires, err := time.Parse(time.RFC3339, remote.ExpireAt); err == nil {
+		session.ExpiresAt = expires
+	}
+
+	return session, resp, nil
+}
+
+// ConsoleDialer connects to the VNC server of a console session.
+type ConsoleDialer func(context.Context) (net.Conn, error)
+
+// DialConsole returns the dialer connecting to the VNC server directly, it
+// requires access to the hypervisor network.
+func DialConsole(session *ConsoleSession) ConsoleDialer {
+	return func(ctx context.Context) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", session.Address())
+	}
+}
+
+// Tunnel returns the dialer connecting to the VNC server through a websocket
+// of the control panel at path, relative to the base URL like the requests of
+// the services. The handshake is authorized with the credentials of the client
+// and passes its rate limits and middlewares. The TLS and proxy settings are
+// taken from the transport of the HTTP client when it is an *http.Transport,
+// only HTTP proxies are supported.
+func (s *ConsoleServiceOp) Tunnel(path string) ConsoleDialer {
+	return func(ctx context.Context) (net.Conn, error) {
+		req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Del("Content-Type")
+		s.client.debugRequest("Console [Tunnel]", req)
+
+		// The limits only apply to the handshake, the tunnel may stay open
+		// for hours
+		release, err := s.client.acquire(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		var ws *websocket.Conn
+		handshake := func(req *http.Request) (*http.Response, error) {
+			conn, err := s.client.dialWebsocket(req)
+			if err != nil {
+				return nil, err
+			}
+			ws = conn
+
+			return &http.Response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		}
+
+		if _, err := s.client.chain(handshake)(req); err != nil {
+			if ws != nil {
+				ws.Close()
+			}
+			return nil, err
+		}
+
+		// VNC is a binary protocol
+		ws.PayloadType = websocket.BinaryFrame
+		return ws, nil
+	}
+}
+
+// dialWebsocket opens the websocket of the request with the dialer, proxy and
+// TLS settings of the HTTP client transport.
+func (c *Client) dialWebsocket(req *http.Request) (*websocket.Conn, error) {
+	ctx := req.Context()
+	transport := c.httpTransport()
+
+	location := *req.URL
+	switch location.Scheme {
+	case "https":
+		location.Scheme = "wss"
+	default:
+		location.Scheme = "ws"
+	}
+
+	config, err := websocket.NewConfig(location.String(), c.BaseURL.String())
+	if err != nil {
+		return nil, err
+	}
+	config.Header = req.Header.Clone()
+
+	var proxy *url.URL
+	dial := (&net.Dialer{}).DialContext
+	tlsConfig := &tls.Config{}
+	if transport != nil {
+		if transport.Proxy != nil {
+			if proxy, err = transport.Proxy(req); err != nil {
+				return nil, err
+			}
+		}
+		if transport.DialContext != nil {
+			dial = transport.DialContext
+		}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+	}
+
+	addr := websocketAuthority(&location)
+	var conn net.Conn
+	if proxy != nil {
+		if proxy.Scheme != "http" {
+			return nil, fmt.Errorf("onappgo: console tunnel supports only http proxies, got %s", proxy.Scheme)
+		}
+
+		if conn, err = dial(ctx, "tcp", websocketAuthority(proxy)); err != nil {
+			return nil, err
+		}
+
+		if err := connectProxy(ctx, conn, proxy, addr, transport.ProxyConnectHeader); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	} else if conn, err = dial(ctx, "tcp", addr); err != nil {
+		return nil, err
+	}
+
+	if location.Scheme == "wss" {
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = location.Hostname()
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	return ws, nil
+}
+
+// connectProxy asks the HTTP proxy on conn to open a tunnel to addr.
+func connectProxy(ctx context.Context, conn net.Conn, proxy *url.URL, addr string, header http.Header) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: header.Clone(),
+	}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+
+	if user := proxy.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("onappgo: proxy %s refused the console tunnel: %s", proxy.Host, resp.Status)
+	}
+
+	return nil
+}
+
+func websocketAuthority(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	switch u.Scheme {
+	case "wss", "https":
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(u.Hostname(), "80")
+}
@@ -0,0 +1,128 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ConsoleProxy forwards the connections of a local VNC client to a console
+// session, e.g.
+//
+//	session, _, _ := client.Console.Open(ctx, vm.ID)
+//	proxy := &onappgo.ConsoleProxy{Session: session, Dial: client.Console.Tunnel(path)}
+//	l, _ := net.Listen("tcp", "127.0.0.1:5900")
+//	go proxy.Serve(ctx, l)
+type ConsoleProxy struct {
+	// Session stops the proxy when it expires, it may be nil if Dial is set
+	Session *ConsoleSession
+
+	// Dial connects to the VNC server, DialConsole(Session) by default
+	Dial ConsoleDialer
+
+	// Logger receives the errors of connecting to the VNC server, they are
+	// discarded by default
+	Logger Logger
+}
+
+// Serve accepts connections on the listener until the context is done or the
+// session expires, every connection is forwarded through its own Dial. It
+// closes the listener and waits for the forwarded connections on return.
+func (p *ConsoleProxy) Serve(ctx context.Context, l net.Listener) error {
+	dial := p.Dial
+	if dial == nil {
+		if p.Session == nil {
+			l.Close()
+			return errors.New("onappgo: console proxy needs a Session or a Dial")
+		}
+		dial = DialConsole(p.Session)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	if p.Session != nil && !p.Session.ExpiresAt.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, p.Session.ExpiresAt)
+	}
+	defer cancel()
+
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		local, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.forward(ctx, local, dial)
+		}()
+	}
+}
+
+func (p *ConsoleProxy) forward(ctx context.Context, local net.Conn, dial ConsoleDialer) {
+	defer local.Close()
+
+	remote, err := dial(ctx)
+	if err != nil {
+		if p.Logger != nil {
+			p.Logger.Errorf("console proxy: connecting %s to the VNC server: %v", local.RemoteAddr(), err)
+		}
+		return
+	}
+	defer remote.Close()
+
+	// Closing both ends unblocks the copy in the other direction
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+
+	go pipe(remote, local)
+	go pipe(local, remote)
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	local.SetDeadline(time.Now())
+	remote.SetDeadline(time.Now())
+	local.Close()
+	remote.Close()
+	<-done
+}
+
+// ListenAndServe serves the proxy on the local address, see Serve. The
+// address the VNC client connects to is sent on ready once listening, which
+// lets the caller pick a free port with "127.0.0.1:0".
+func (p *ConsoleProxy) ListenAndServe(ctx context.Context, addr string, ready chan<- net.Addr) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if ready != nil {
+		ready <- l.Addr()
+	}
+
+	err = p.Serve(ctx, l)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,202 @@
+package onappgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func TestConsole_Open(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines/1/console.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"remote_access_session":{"id":3,"port":5901,"remote_key":"abc","virtual_machine_id":1,
+			"expire_at":"2026-10-16T12:00:00Z"}}`)
+	})
+	mux.HandleFunc("/virtual_machines/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"virtual_machine":{"id":1,"local_remote_access_ip_address":"10.0.0.2",
+			"local_remote_access_port":5900,"remote_access_password":"secret"}}`)
+	})
+
+	session, _, err := client.Console.Open(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, &ConsoleSession{
+		ID:               3,
+		VirtualMachineID: 1,
+		Host:             "10.0.0.2",
+		Port:             5901,
+		Password:         "secret",
+		RemoteKey:        "abc",
+		ExpiresAt:        time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}, session)
+	require.Equal(t, "10.0.0.2:5901", session.Address())
+	require.True(t, session.Expired())
+}
+
+// testConsoleProxy serves the proxy on a free local port, writes a message
+// through it and returns what came back.
+func testConsoleProxy(t *testing.T, proxy *ConsoleProxy) string {
+	ctx, cancel := context.WithCancel(ctx)
+	ready := make(chan net.Addr, 1)
+	served := make(chan error, 1)
+	go func() {
+		served <- proxy.ListenAndServe(ctx, "127.0.0.1:0", ready)
+	}()
+
+	conn, err := net.Dial("tcp", (<-ready).String())
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("RFB 003.008\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 12)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	conn.Close()
+
+	cancel()
+	require.NoError(t, <-served)
+
+	return string(buf)
+}
+
+func TestConsoleProxy_direct(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(conn, conn)
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	session := &ConsoleSession{Host: addr.IP.String(), Port: addr.Port}
+
+	require.Equal(t, "RFB 003.008\n", testConsoleProxy(t, &ConsoleProxy{Session: session}))
+}
+
+func TestConsoleProxy_tunnel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.Handle("/console/abc", websocket.Handler(func(ws *websocket.Conn) {
+		user, _, ok := ws.Request().BasicAuth()
+		if !ok || user != email {
+			return
+		}
+		io.Copy(ws, ws)
+	}))
+
+	session := &ConsoleSession{RemoteKey: "abc"}
+	proxy := &ConsoleProxy{Session: session, Dial: client.Console.Tunnel("console/" + session.RemoteKey)}
+
+	require.Equal(t, "RFB 003.008\n", testConsoleProxy(t, proxy))
+}
+
+func TestConsoleProxy_tunnelTransport(t *testing.T) {
+	ws := httptest.NewTLSServer(websocket.Handler(func(ws *websocket.Conn) {
+		if _, _, ok := ws.Request().BasicAuth(); !ok || ws.Request().Header.Get(headerRequestID) == "" {
+			return
+		}
+		io.Copy(ws, ws)
+	}))
+	defer ws.Close()
+
+	var connects int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		atomic.AddInt32(&connects, 1)
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprint(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+
+		go io.Copy(upstream, conn)
+		io.Copy(conn, upstream)
+	}))
+	defer proxy.Close()
+
+	// The caller's HTTP client trusts the test certificate and uses the proxy,
+	// no transport option of the SDK is set
+	hc := ws.Client()
+	proxyURL, _ := url.Parse(proxy.URL)
+	hc.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+
+	c, err := New(hc, SetBaseURL(ws.URL), SetBasicAuth(email, token), SetMiddlewares(RequestIDMiddleware()))
+	require.NoError(t, err)
+
+	session := &ConsoleSession{RemoteKey: "abc"}
+	require.Equal(t, "RFB 003.008\n", testConsoleProxy(t, &ConsoleProxy{Session: session, Dial: c.Console.Tunnel("console/abc")}))
+	require.Equal(t, int32(1), atomic.LoadInt32(&connects))
+}
+
+func TestConsoleProxy_logsDialErrors(t *testing.T) {
+	var buf bytes.Buffer
+	proxy := &ConsoleProxy{
+		Dial: func(context.Context) (net.Conn, error) {
+			return nil, errors.New("tunnel refused")
+		},
+		Logger: NewStdLogger(log.New(&buf, "", 0), LogLevelError),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	ready := make(chan net.Addr, 1)
+	served := make(chan error, 1)
+	go func() {
+		served <- proxy.ListenAndServe(ctx, "127.0.0.1:0", ready)
+	}()
+
+	conn, err := net.Dial("tcp", (<-ready).String())
+	require.NoError(t, err)
+	_, err = conn.Read(make([]byte, 1))
+	require.Equal(t, io.EOF, err)
+	conn.Close()
+
+	cancel()
+	require.NoError(t, <-served)
+	require.Contains(t, buf.String(), "tunnel refused")
+}
+
+func TestConsoleProxy_noSession(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	err = (&ConsoleProxy{}).Serve(ctx, l)
+	require.EqualError(t, err, "onappgo: console proxy needs a Session or a Dial")
+}
@@ -0,0 +1,147 @@
+package onappgo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type skipAuthorizationKey struct{}
+
+// Credentials authorize the requests built by NewRequest.
+type Credentials interface {
+	// Authorize sets the authorization of the request
+	Authorize(req *http.Request) error
+
+	// Username returns the user the requests are made on behalf of
+	Username() string
+}
+
+// APIKeyCredentials authorize requests with the user email and API key, see
+// UsersService.MakeNewAPIKey.
+type APIKeyCredentials struct {
+	Email  string
+	APIKey string
+}
+
+var _ Credentials = &APIKeyCredentials{}
+
+// Authorize sets the Basic authorization header from email and API key.
+func (c *APIKeyCredentials) Authorize(req *http.Request) error {
+	req.SetBasicAuth(c.Email, c.APIKey)
+	return nil
+}
+
+// Username returns the email.
+func (c *APIKeyCredentials) Username() string {
+	return c.Email
+}
+
+// PasswordCredentials authorize requests with the user login and password.
+type PasswordCredentials struct {
+	Login    string
+	Password string
+}
+
+var _ Credentials = &PasswordCredentials{}
+
+// Authorize sets the Basic authorization header from login and password.
+func (c *PasswordCredentials) Authorize(req *http.Request) error {
+	req.SetBasicAuth(c.Login, c.Password)
+	return nil
+}
+
+// Username returns the login.
+func (c *PasswordCredentials) Username() string {
+	return c.Login
+}
+
+// RotatingCredentials delegates to credentials which can be replaced at
+// runtime, it is safe for concurrent use.
+type RotatingCredentials struct {
+	mu          sync.RWMutex
+	credentials Credentials
+}
+
+var _ Credentials = &RotatingCredentials{}
+
+// NewRotatingCredentials returns RotatingCredentials which start with the
+// given credentials.
+func NewRotatingCredentials(initial Credentials) *RotatingCredentials {
+	return &RotatingCredentials{credentials: initial}
+}
+
+// Rotate replaces the credentials used by the following requests.
+func (c *RotatingCredentials) Rotate(credentials Credentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.credentials = credentials
+}
+
+// RotateAPIKey generates a new API key for the user and switches to it.
+//
+//	_, err := creds.RotateAPIKey(ctx, client, userID, "admin@example.com")
+func (c *RotatingCredentials) RotateAPIKey(ctx context.Context, client *Client, userID int, email string) (*Response, error) {
+	key, resp, err := client.Users.MakeNewAPIKey(ctx, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	c.Rotate(&APIKeyCredentials{Email: email, APIKey: key})
+	return resp, nil
+}
+
+// Current returns the credentials in use.
+func (c *RotatingCredentials) Current() Credentials {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.credentials
+}
+
+// Authorize delegates to the current credentials.
+func (c *RotatingCredentials) Authorize(req *http.Request) error {
+	current := c.Current()
+	if current == nil {
+		return nil
+	}
+
+	return current.Authorize(req)
+}
+
+// Username delegates to the current credentials.
+func (c *RotatingCredentials) Username() string {
+	current := c.Current()
+	if current == nil {
+		return ""
+	}
+
+	return current.Username()
+}
+
+// SetCredentials is a client option for setting the credentials of API calls.
+func SetCredentials(credentials Credentials) ClientOpt {
+	return func(c *Client) error {
+		c.credentials = credentials
+		return nil
+	}
+}
+
+// WithoutAuthorization returns a context for requests to unauthenticated
+// endpoints, NewRequest doesn't set the Authorization header for them.
+func WithoutAuthorization(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipAuthorizationKey{}, true)
+}
+
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	if c.credentials == nil {
+		return nil
+	}
+
+	if skip, _ := ctx.Value(skipAuthorizationKey{}).(bool); skip {
+		return nil
+	}
+
+	return c.credentials.Authorize(req)
+}
@@ -0,0 +1,58 @@
+package onappgo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRequest_credentials(t *testing.T) {
+	c, err := New(nil, SetCredentials(&APIKeyCredentials{Email: email, APIKey: token}))
+	require.NoError(t, err)
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "/foo", nil)
+	user, password, ok := req.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, email, user)
+	require.Equal(t, token, password)
+
+	req, _ = c.NewRequest(WithoutAuthorization(ctx), http.MethodGet, "/foo", nil)
+	require.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestNewRequest_noCredentials(t *testing.T) {
+	c, err := New(nil, SetBasicAuth("", ""))
+	require.NoError(t, err)
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "/foo", nil)
+	require.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestRotatingCredentials_RotateAPIKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	creds := NewRotatingCredentials(&PasswordCredentials{Login: "admin", Password: "password"})
+	require.NoError(t, SetCredentials(creds)(client))
+
+	mux.HandleFunc("/users/1/make_new_api_key.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		user, password, _ := r.BasicAuth()
+		require.Equal(t, "admin", user)
+		require.Equal(t, "password", password)
+
+		fmt.Fprint(w, `{"user":{"id":1,"api_key":"new-key"}}`)
+	})
+
+	_, err := creds.RotateAPIKey(ctx, client, 1, email)
+	require.NoError(t, err)
+	require.Equal(t, email, creds.Username())
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/foo", nil)
+	user, password, _ := req.BasicAuth()
+	require.Equal(t, email, user)
+	require.Equal(t, "new-key", password)
+}
@@ -0,0 +1,199 @@
+package onappgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// crud implements the List, Get, Create, Edit and Delete requests shared by
+// the services, so that they validate arguments, log and decode the same way.
+type crud[T any] struct {
+	// name prefixes the debug log of the requests, e.g. "Bucket"
+	name string
+
+	// path of the collection relative to the base URL without the format,
+	// verbs are replaced with the IDs of the parent objects by at,
+	// e.g. "users/%d/user_white_lists"
+	path string
+
+	// root is the key objects and create requests are wrapped in
+	root string
+}
+
+// at returns the crud of the collection below the parent objects.
+func (r crud[T]) at(parents ...interface{}) crud[T] {
+	r.path = fmt.Sprintf(r.path, parents...)
+	return r
+}
+
+// in returns the crud of the same objects in another collection, e.g. the
+// disks of a virtual machine.
+func (r crud[T]) in(path string, parents ...interface{}) crud[T] {
+	r.path = fmt.Sprintf(path, parents...)
+	return r
+}
+
+// join returns the crud of the joins of a target, paths holds the collection
+// path of every target type.
+func (r crud[T]) join(paths map[string]string, targetJoinType string, targetJoinID int) (crud[T], error) {
+	path, ok := paths[targetJoinType]
+	if !ok {
+		return r, godo.NewArgError(r.name+": wrong TargetJoinType", targetJoinType)
+	}
+
+	return r.in(path, targetJoinID), nil
+}
+
+func (r crud[T]) collection() string {
+	return r.path + apiFormat
+}
+
+func (r crud[T]) member(id int) string {
+	return r.memberKey(strconv.Itoa(id))
+}
+
+// memberKey is the path of an object which is not identified by a number.
+func (r crud[T]) memberKey(key string) string {
+	return r.path + "/" + key + apiFormat
+}
+
+// list fetches a single page of the collection, opt is a *ListOptions or
+// options embedding it. Items without the root decode to zero values.
+func (r crud[T]) list(ctx context.Context, c *Client, opt interface{}) ([]T, *Response, error) {
+	path, err := addOptions(r.collection(), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := r.request(ctx, c, "List", http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []map[string]T
+	resp, err := c.Do(ctx, req, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	arr := make([]T, len(out))
+	for i := range arr {
+		arr[i] = out[i][r.root]
+	}
+
+	return arr, resp, err
+}
+
+func (r crud[T]) get(ctx context.Context, c *Client, id int) (*T, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	return r.do(ctx, c, "Get", http.MethodGet, r.member(id), nil)
+}
+
+// create posts the request wrapped in root.
+func (r crud[T]) create(ctx context.Context, c *Client, createRequest interface{}) (*T, *Response, error) {
+	if isNil(createRequest) {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
+	}
+
+	return r.do(ctx, c, "Create", http.MethodPost, r.collection(), wrapResource(r.root, createRequest))
+}
+
+// edit puts the request as is, the API does not expect it wrapped.
+func (r crud[T]) edit(ctx context.Context, c *Client, id int, editRequest interface{}) (*Response, error) {
+	if id < 1 {
+		return nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	if isNil(editRequest) {
+		return nil, godo.NewArgError("editRequest", "cannot be nil")
+	}
+
+	return r.send(ctx, c, "Edit", http.MethodPut, r.member(id), editRequest)
+}
+
+// delete deletes the object, meta is encoded into the query.
+func (r crud[T]) delete(ctx context.Context, c *Client, id int, meta interface{}) (*Response, error) {
+	if id < 1 {
+		return nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	path, err := addOptions(r.member(id), meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.send(ctx, c, "Delete", http.MethodDelete, path, nil)
+}
+
+// deleteTransaction deletes the object and returns the head of the
+// transaction chain the API scheduled for it.
+func (r crud[T]) deleteTransaction(ctx context.Context, c *Client, id int, meta interface{}, filter *TransactionFilter) (*Transaction, *Response, error) {
+	started := time.Now()
+	resp, err := r.delete(withTransactionAudit(ctx), c, id, meta)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lastTransaction(ctx, c, started, resp, filter)
+}
+
+// do sends the request of the operation op and decodes the object of the
+// response. Like the services did before, an object missing from the response
+// is nil and a response without a body is an error.
+func (r crud[T]) do(ctx context.Context, c *Client, op, method, path string, body interface{}) (*T, *Response, error) {
+	req, err := r.request(ctx, c, op, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root map[string]*T
+	resp, err := c.Do(ctx, req, &root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root[r.root], resp, err
+}
+
+// send sends the request of the operation op and ignores the response body.
+func (r crud[T]) send(ctx context.Context, c *Client, op, method, path string, body interface{}) (*Response, error) {
+	req, err := r.request(ctx, c, op, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+func (r crud[T]) request(ctx context.Context, c *Client, op, method, path string, body interface{}) (*http.Request, error) {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	c.debugRequest(fmt.Sprintf("%s [%s]", r.name, op), req)
+
+	return req, nil
+}
+
+// isNil reports whether v is nil or a nil pointer, map or slice.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package onappgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRUD_listHonorsOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, path := range []string{"/billing/buckets/1/access_controls.json", "/billing/buckets/1/rate_cards.json"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "2", r.URL.Query().Get("page"))
+			require.Equal(t, "5", r.URL.Query().Get("per_page"))
+			fmt.Fprint(w, `[]`)
+		})
+	}
+
+	opt := &ListOptions{Page: 2, PerPage: 5}
+
+	_, _, err := client.AccessControls.List(ctx, 1, opt)
+	require.NoError(t, err)
+
+	_, _, err = client.RateCards.List(ctx, 1, opt)
+	require.NoError(t, err)
+}
+
+func TestCRUD_relativePaths(t *testing.T) {
+	setup()
+	defer teardown()
+
+	base, err := url.Parse(server.URL + "/api/")
+	require.NoError(t, err)
+	client.BaseURL = base
+
+	mux.HandleFunc("/api/users/1/user_white_lists/2.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"user_white_list":{"id":2,"user_id":1}}`)
+	})
+	mux.HandleFunc("/api/software_licenses/3.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"software_license":{"id":3}}`)
+	})
+
+	list, _, err := client.UserWhiteLists.Get(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, list.ID)
+
+	license, _, err := client.SoftwareLicenses.Get(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, license.ID)
+}
+
+func TestCRUD_validationAndLogging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	require.NoError(t, SetLogger(NewStdLogger(log.New(&buf, "", 0), LogLevelDebug))(client))
+
+	mux.HandleFunc("/billing/buckets.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"bucket":{"id":1,"label":"b"}}]`)
+	})
+	mux.HandleFunc("/billing/buckets/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"bucket":{"id":1,"label":"b"}}`)
+	})
+
+	buckets, _, err := client.Buckets.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Bucket{{ID: 1, Label: "b"}}, buckets)
+
+	bucket, _, err := client.Buckets.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "b", bucket.Label)
+
+	require.Contains(t, buf.String(), "Bucket [List] req: GET")
+	require.Contains(t, buf.String(), "Bucket [Get] req: GET")
+
+	_, _, err = client.Buckets.Get(ctx, 0)
+	require.EqualError(t, err, "id is invalid because cannot be less than 1")
+
+	_, _, err = client.Buckets.Create(ctx, nil)
+	require.EqualError(t, err, "createRequest is invalid because cannot be nil")
+
+	_, err = client.Buckets.Edit(ctx, 1, nil)
+	require.EqualError(t, err, "editRequest is invalid because cannot be nil")
+}
+
+func TestCRUD_decoding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/billing/buckets.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		fmt.Fprint(w, `[{"bucket":{"id":1}},{"other":{"id":2}}]`)
+	})
+	mux.HandleFunc("/billing/buckets/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"other":{"id":1}}`)
+	})
+
+	buckets, _, err := client.Buckets.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Bucket{{ID: 1}, {}}, buckets)
+
+	bucket, _, err := client.Buckets.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Nil(t, bucket)
+
+	_, _, err = client.Buckets.Create(ctx, &BucketCreateRequest{Label: "b"})
+	require.Equal(t, io.EOF, err)
+}
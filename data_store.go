@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -17,6 +16,7 @@ const dataStoresIoLimitsBasePath string = "settings/data_stores/%d/io_limits"
 // https://docs.onapp.com/apim/latest/data-stores
 type DataStoresService interface {
 	List(context.Context, *ListOptions) ([]DataStore, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]DataStore, *Response, error)
 	Get(context.Context, int) (*DataStore, *Response, error)
 	Create(context.Context, *DataStoreCreateRequest) (*DataStore, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -33,6 +33,8 @@ type DataStoresServiceOp struct {
 
 var _ DataStoresService = &DataStoresServiceOp{}
 
+var dataStoresCRUD = crud[DataStore]{name: "DataStore", path: dataStoresBasePath, root: "data_store"}
+
 type AdminAttributes struct {
 	Username string `json:"username,omitempty"`
 	Password string `json:"password,omitempty"`
@@ -116,132 +118,38 @@ type rootIoLimits struct {
 	IoLimits *IoLimits `json:"io_limits"`
 }
 
-type dataStoreCreateRequestRoot struct {
-	DataStoreCreateRequest *DataStoreCreateRequest `json:"data_store"`
-}
-
-type dataStoreRoot struct {
-	DataStore *DataStore `json:"data_store"`
-}
-
 func (d DataStoreCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStores.
 func (s *DataStoresServiceOp) List(ctx context.Context, opt *ListOptions) ([]DataStore, *Response, error) {
-	path := dataStoresBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]DataStore
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]DataStore, len(out))
-	for i := range arr {
-		arr[i] = out[i]["data_store"]
-	}
+	return dataStoresCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of DataStores.
+func (s *DataStoresServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]DataStore, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual DataStore.
 func (s *DataStoresServiceOp) Get(ctx context.Context, id int) (*DataStore, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoresBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(dataStoreRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.DataStore, resp, err
+	return dataStoresCRUD.get(ctx, s.client, id)
 }
 
 // Create DataStore.
 func (s *DataStoresServiceOp) Create(ctx context.Context, createRequest *DataStoreCreateRequest) (*DataStore, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("DataStore createRequest", "cannot be nil")
-	}
-
-	path := dataStoresBasePath + apiFormat
-	rootRequest := &dataStoreCreateRequestRoot{
-		DataStoreCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("DataStore [Create] req: ", req)
-
-	root := new(dataStoreRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.DataStore, resp, err
+	return dataStoresCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete DataStore.
 func (s *DataStoresServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoresBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("DataStore [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return dataStoresCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit DataStore.
 func (s *DataStoresServiceOp) Edit(ctx context.Context, id int, editRequest *DataStoreEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("DataStore [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoresBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("DataStore [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return dataStoresCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 // IoLimits edit io limits for DataStore.
@@ -264,7 +172,7 @@ func (s *DataStoresServiceOp) IoLimits(ctx context.Context, id int, editRequest
 	if err != nil {
 		return nil, err
 	}
-	log.Println("DataStore [IoLimits]  req: ", req)
+	s.client.debugRequest("DataStore [IoLimits]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
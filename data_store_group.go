@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -19,6 +18,7 @@ const dataStoreAttachedToDataStoreZone string = dataStoreGroupsBasePath + "/%d/d
 // https://docs.onapp.com/apim/latest/data-store-zones
 type DataStoreGroupsService interface {
 	List(context.Context, *ListOptions) ([]DataStoreGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]DataStoreGroup, *Response, error)
 	Get(context.Context, int) (*DataStoreGroup, *Response, error)
 	Create(context.Context, *DataStoreGroupCreateRequest) (*DataStoreGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -38,6 +38,8 @@ type DataStoreGroupsServiceOp struct {
 
 var _ DataStoreGroupsService = &DataStoreGroupsServiceOp{}
 
+var dataStoreGroupsCRUD = crud[DataStoreGroup]{name: "DataStoreGroup", path: dataStoreGroupsBasePath, root: "data_store_group"}
+
 // DataStoreGroup represents a DataStoreGroup
 type DataStoreGroup struct {
 	ID                int                `json:"id,omitempty"`
@@ -77,132 +79,38 @@ type DataStoreGroupEditRequest struct {
 	PreconfiguredOnly bool   `json:"preconfigured_only,bool"`
 }
 
-type dataStoreGroupCreateRequestRoot struct {
-	DataStoreGroupCreateRequest *DataStoreGroupCreateRequest `json:"data_store_group"`
-}
-
-type dataStoreGroupRoot struct {
-	DataStoreGroup *DataStoreGroup `json:"data_store_group"`
-}
-
 func (d DataStoreGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreGroups.
 func (s *DataStoreGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]DataStoreGroup, *Response, error) {
-	path := dataStoreGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]DataStoreGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]DataStoreGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["data_store_group"]
-	}
+	return dataStoreGroupsCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of DataStoreGroups.
+func (s *DataStoreGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]DataStoreGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual DataStoreGroup.
 func (s *DataStoreGroupsServiceOp) Get(ctx context.Context, id int) (*DataStoreGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoreGroupsBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(dataStoreGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.DataStoreGroup, resp, err
+	return dataStoreGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create DataStoreGroup.
 func (s *DataStoreGroupsServiceOp) Create(ctx context.Context, createRequest *DataStoreGroupCreateRequest) (*DataStoreGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("DataStoreGroup createRequest", "cannot be nil")
-	}
-
-	path := dataStoreGroupsBasePath + apiFormat
-	rootRequest := &dataStoreGroupCreateRequestRoot{
-		DataStoreGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("DataStoreGroup [Create] req: ", req)
-
-	root := new(dataStoreGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.DataStoreGroup, resp, err
+	return dataStoreGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete DataStoreGroup.
 func (s *DataStoreGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoreGroupsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("DataStoreGroup [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return dataStoreGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit DataStoreGroup.
 func (s *DataStoreGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *DataStoreGroupEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("DataStoreGroup [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", dataStoreGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("DataStoreGroup [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return dataStoreGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 // Attach data store to the DataStoreGroup.
@@ -217,7 +125,7 @@ func (s *DataStoreGroupsServiceOp) Attach(ctx context.Context, resID int, id int
 	if err != nil {
 		return nil, err
 	}
-	log.Println("DataStoreGroup [Attach]  req: ", req)
+	s.client.debugRequest("DataStoreGroup [Attach]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -234,7 +142,7 @@ func (s *DataStoreGroupsServiceOp) Detach(ctx context.Context, resID int, id int
 	if err != nil {
 		return nil, err
 	}
-	log.Println("DataStoreGroup [Detach]  req: ", req)
+	s.client.debugRequest("DataStoreGroup [Detach]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -247,7 +155,7 @@ func (s *DataStoreGroupsServiceOp) AttachedDataStores(ctx context.Context, resID
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("DataStoreGroup [AttachedDataStores]  req: ", req)
+	s.client.debugRequest("DataStoreGroup [AttachedDataStores]", req)
 
 	var out []map[string]DataStore
 	resp, err := s.client.Do(ctx, req, &out)
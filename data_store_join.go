@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -29,6 +27,8 @@ type DataStoreJoinsServiceOp struct {
 
 var _ DataStoreJoinsService = &DataStoreJoinsServiceOp{}
 
+var dataStoreJoinsCRUD = crud[DataStoreJoin]{name: "DataStoreJoin", root: "data_store_join"}
+
 // DataStoreJoin represents a DataStoreJoin
 type DataStoreJoin struct {
 	ID             int    `json:"id,omitempty"`
@@ -57,135 +57,62 @@ type dataStoreJoinCreateRequestRoot struct {
 	DataStoreID int `json:"data_store_id,omitempty"`
 }
 
-type dataStoreJoinRoot struct {
-	DataStoreJoin *DataStoreJoin `json:"data_store_join"`
-}
-
 func (d DataStoreJoinCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreJoins.
 func (s *DataStoreJoinsServiceOp) List(ctx context.Context, createRequest *DataStoreJoinCreateRequest, opt *ListOptions) ([]DataStoreJoin, *Response, error) {
-	path := ""
-	if val, ok := dataStoreJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("DataStoreJoin List: wrong TargetJoinType", createRequest.TargetJoinType)
-	}
-
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
+	if createRequest == nil {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := dataStoreJoinsCRUD.join(dataStoreJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var out []map[string]DataStoreJoin
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]DataStoreJoin, len(out))
-	for i := range arr {
-		arr[i] = out[i]["data_store_join"]
-	}
-
-	return arr, resp, err
+	return r.list(ctx, s.client, opt)
 }
 
 // Get individual DataStoreJoin.
 func (s *DataStoreJoinsServiceOp) Get(ctx context.Context, targetJoinType string, targetJoinID int, id int) (*DataStoreJoin, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := dataStoreJoinPaths[targetJoinType]; ok {
-		path = fmt.Sprintf(val, targetJoinID)
-	} else {
-		return nil, nil, godo.NewArgError("DataStoreJoin Get: wrong TargetJoinType", targetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := dataStoreJoinsCRUD.join(dataStoreJoinPaths, targetJoinType, targetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	root := new(dataStoreJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.DataStoreJoin, resp, err
+	return r.get(ctx, s.client, id)
 }
 
 // Create DataStoreJoin.
 func (s *DataStoreJoinsServiceOp) Create(ctx context.Context, createRequest *DataStoreJoinCreateRequest) (*DataStoreJoin, *Response, error) {
 	if createRequest == nil {
-		return nil, nil, godo.NewArgError("DataStoreJoin createRequest", "cannot be nil")
-	}
-
-	path := ""
-	if val, ok := dataStoreJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("DataStoreJoin Create: wrong TargetJoinType", createRequest.TargetJoinType)
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	rootRequest := &dataStoreJoinCreateRequestRoot{
-		DataStoreID: createRequest.DataStoreID,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
+	r, err := dataStoreJoinsCRUD.join(dataStoreJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("DataStoreJoin [Create] req: ", req)
 
-	root := new(dataStoreJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+	rootRequest := &dataStoreJoinCreateRequestRoot{
+		DataStoreID: createRequest.DataStoreID,
 	}
 
-	return root.DataStoreJoin, resp, err
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), rootRequest)
 }
 
 // Delete DataStoreJoin.
 func (s *DataStoreJoinsServiceOp) Delete(ctx context.Context, deleteRequest *DataStoreJoinDeleteRequest, meta interface{}) (*Response, error) {
 	if deleteRequest == nil {
-		return nil, godo.NewArgError("DataStoreJoin deleteRequest", "cannot be nil")
-	}
-
-	if deleteRequest.ID < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := dataStoreJoinPaths[deleteRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, deleteRequest.TargetJoinID)
-	} else {
-		return nil, godo.NewArgError("DataStoreJoin Delete: wrong TargetJoinType", deleteRequest.TargetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, deleteRequest.ID, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
+		return nil, godo.NewArgError("deleteRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	r, err := dataStoreJoinsCRUD.join(dataStoreJoinPaths, deleteRequest.TargetJoinType, deleteRequest.TargetJoinID)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("DataStoreJoin [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.delete(ctx, s.client, deleteRequest.ID, meta)
 }
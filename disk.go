@@ -2,20 +2,19 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
 
 const disksBasePath string = "settings/disks"
+const virtualMachineDisksBasePath string = "virtual_machines/%d/disks"
 
 // DisksService is an interface for interfacing with the Disk
 // endpoints of the OnApp API
 // https://docs.onapp.com/apim/latest/disks
 type DisksService interface {
 	List(context.Context, *ListOptions) ([]Disk, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Disk, *Response, error)
 	Get(context.Context, int) (*Disk, *Response, error)
 	Create(context.Context, *DiskCreateRequest) (*Disk, *Response, error)
 	Delete(context.Context, int, interface{}) (*Transaction, *Response, error)
@@ -30,6 +29,8 @@ type DisksServiceOp struct {
 
 var _ DisksService = &DisksServiceOp{}
 
+var disksCRUD = crud[Disk]{name: "Disk", path: disksBasePath, root: "disk"}
+
 // Disk - represent disk from Virtual Machine
 type Disk struct {
 	AddToFreebsdFstab              bool                           `json:"add_to_freebsd_fstab,bool"`
@@ -100,65 +101,23 @@ type DiskEditRequest struct {
 	Mounted           string `json:"mounted,omitempty"`
 }
 
-type diskCreateRequestRoot struct {
-	DiskCreateRequest *DiskCreateRequest `json:"disk"`
-}
-
-type diskRoot struct {
-	Disk *Disk `json:"disk"`
-}
-
 func (d DiskCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Disks in the cloud.
 func (s *DisksServiceOp) List(ctx context.Context, opt *ListOptions) ([]Disk, *Response, error) {
-	path := disksBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Disk
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Disk, len(out))
-	for i := range arr {
-		arr[i] = out[i]["disk"]
-	}
+	return disksCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of Disks.
+func (s *DisksServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Disk, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Disk.
 func (s *DisksServiceOp) Get(ctx context.Context, id int) (*Disk, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", disksBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(diskRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Disk, resp, err
+	return disksCRUD.get(ctx, s.client, id)
 }
 
 // Create Disk.
@@ -167,75 +126,20 @@ func (s *DisksServiceOp) Create(ctx context.Context, createRequest *DiskCreateRe
 		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf("%s/%d/disks%s", virtualMachineBasePath, createRequest.VirtualMachineID, apiFormat)
-
-	rootRequest := &diskCreateRequestRoot{
-		DiskCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("Disk [Create]  req: ", req)
-
-	root := new(diskRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Disk, resp, err
+	return disksCRUD.in(virtualMachineDisksBasePath, createRequest.VirtualMachineID).create(ctx, s.client, createRequest)
 }
 
 // Delete Disk.
 func (s *DisksServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Transaction, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", disksBasePath, id, apiFormat)
-
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("Disk [Delete]  req: ", req)
-
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	filter := struct {
-		ParentID   int
-		ParentType string
-	}{
+	filter := &TransactionFilter{
 		ParentID:   id,
 		ParentType: "Disk",
 	}
 
-	return lastTransaction(ctx, s.client, filter)
+	return disksCRUD.deleteTransaction(ctx, s.client, id, meta, filter)
 }
 
 // Edit Disk.
 func (s *DisksServiceOp) Edit(ctx context.Context, id int, editRequest *DiskEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", disksBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Disk [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return disksCRUD.edit(ctx, s.client, id, editRequest)
 }
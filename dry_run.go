@@ -0,0 +1,104 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrDryRun is returned by Client.Do for mutating requests of a dry-run client,
+// which are recorded into the Plan instead of being sent.
+var ErrDryRun = errors.New("onappgo: dry run, request not sent")
+
+// PlannedRequest is a mutating request captured in dry-run mode. Path is
+// relative to the base URL and includes the query, secrets in Body are
+// redacted.
+type PlannedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+func (r PlannedRequest) String() string {
+	if len(r.Body) == 0 {
+		return r.Method + " " + r.Path
+	}
+
+	return fmt.Sprintf("%s %s %s", r.Method, r.Path, r.Body)
+}
+
+// Plan collects the requests of a dry-run client, it is safe for concurrent use.
+type Plan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// Requests returns the captured requests in the order they were made.
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := make([]PlannedRequest, len(p.requests))
+	copy(res, p.requests)
+
+	return res
+}
+
+// Reset drops the captured requests.
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = nil
+}
+
+// String renders the plan one request per line.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, r := range p.Requests() {
+		b.WriteString(r.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (p *Plan) add(r PlannedRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, r)
+}
+
+// SetDryRun is a client option for the dry-run mode: POST, PUT, PATCH and
+// DELETE requests are recorded into the plan and fail with ErrDryRun, so that
+// services return zero values. GET requests are still sent.
+func SetDryRun(plan *Plan) ClientOpt {
+	return func(c *Client) error {
+		c.plan = plan
+		return nil
+	}
+}
+
+// planned reports whether the request is captured by the dry-run plan.
+func (c *Client) planned(req *http.Request) bool {
+	if c.plan == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+
+	c.plan.add(PlannedRequest{
+		Method: req.Method,
+		Path:   c.relativePath(req),
+		Body:   jsonRequestBody(req),
+	})
+	return true
+}
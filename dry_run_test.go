@@ -0,0 +1,52 @@
+package onappgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plan := &Plan{}
+	require.NoError(t, SetDryRun(plan)(client))
+
+	mux.HandleFunc("/virtual_machines/1.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"virtual_machine":{"id":1,"label":"web"}}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s", r.Method, r.URL)
+	})
+
+	vm, _, err := client.VirtualMachines.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "web", vm.Label)
+
+	trx, resp, err := client.VirtualMachineActions.Startup(ctx, 1)
+	require.True(t, errors.Is(err, ErrDryRun))
+	require.Nil(t, trx)
+	require.Nil(t, resp)
+
+	user, _, err := client.Users.Create(ctx, &UserCreateRequest{Login: "jdoe", Password: "hunter2"})
+	require.True(t, errors.Is(err, ErrDryRun))
+	require.Nil(t, user)
+
+	_, _, err = client.Disks.Delete(ctx, 5, nil)
+	require.True(t, errors.Is(err, ErrDryRun))
+
+	requests := plan.Requests()
+	require.Len(t, requests, 3)
+	require.Equal(t, PlannedRequest{Method: http.MethodPost, Path: "/virtual_machines/1/startup.json"}, requests[0])
+	require.Equal(t, "/users.json", requests[1].Path)
+	require.JSONEq(t, `{"user":{"login":"jdoe","password":"[REDACTED]"}}`, string(requests[1].Body))
+	require.Equal(t, "DELETE /settings/disks/5.json", requests[2].String())
+
+	plan.Reset()
+	require.Empty(t, plan.Requests())
+}
@@ -0,0 +1,115 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// errorBaseField is the key of messages which are not related to a single field.
+const errorBaseField = "base"
+
+// Sentinel errors which an *ErrorResponse matches with errors.Is, depending on
+// the HTTP status code returned by the OnApp API.
+var (
+	// ErrNotFound - 404 Not Found
+	ErrNotFound = errors.New("onappgo: resource not found")
+
+	// ErrUnauthorized - 401 Unauthorized
+	ErrUnauthorized = errors.New("onappgo: unauthorized")
+
+	// ErrForbidden - 403 Forbidden
+	ErrForbidden = errors.New("onappgo: forbidden")
+
+	// ErrValidation - 400 Bad Request or 422 Unprocessable Entity, per field
+	// messages are in ErrorResponse.Errors
+	ErrValidation = errors.New("onappgo: validation failed")
+
+	// ErrConflict - 409 Conflict or 423 Locked
+	ErrConflict = errors.New("onappgo: resource conflict or locked")
+
+	// ErrServer - any 5xx status code
+	ErrServer = errors.New("onappgo: server error")
+)
+
+// Is reports whether the ErrorResponse matches one of the sentinel errors.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+
+	code := r.Response.StatusCode
+
+	switch target {
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrValidation:
+		return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+	case ErrConflict:
+		return code == http.StatusConflict || code == http.StatusLocked
+	case ErrServer:
+		return code >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// FieldErrors returns error messages reported for the given field, use "base"
+// for messages which are not related to a single field.
+func (r *ErrorResponse) FieldErrors(field string) []string {
+	return r.Errors[field]
+}
+
+// parseErrors reads error messages from the different body shapes used by
+// the OnApp API:
+//
+//	{"errors": {"field": ["message"]}}
+//	{"errors": ["message"]}
+//	{"error": "message"}
+//	["message"]
+func parseErrors(data []byte) map[string][]string {
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil
+	}
+
+	errs := make(map[string][]string)
+
+	switch b := body.(type) {
+	case []interface{}:
+		addErrorMessages(errs, errorBaseField, b)
+	case map[string]interface{}:
+		if fields, ok := b["errors"].(map[string]interface{}); ok {
+			for field, messages := range fields {
+				addErrorMessages(errs, field, messages)
+			}
+		} else {
+			addErrorMessages(errs, errorBaseField, b["errors"])
+		}
+
+		addErrorMessages(errs, errorBaseField, b["error"])
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func addErrorMessages(errs map[string][]string, field string, messages interface{}) {
+	switch m := messages.(type) {
+	case string:
+		errs[field] = append(errs[field], m)
+	case []interface{}:
+		for _, v := range m {
+			if str, ok := v.(string); ok {
+				errs[field] = append(errs[field], str)
+			}
+		}
+	}
+}
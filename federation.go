@@ -2,10 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-
-	"github.com/digitalocean/godo"
 )
 
 const hypervisorZonesBasePath string = "federation/hypervisor_zones/unsubscribed"
@@ -15,6 +11,7 @@ const hypervisorZonesBasePath string = "federation/hypervisor_zones/unsubscribed
 // See: https://docs.onapp.com/apim/latest/federation/get-list-of-federated-resources
 type HypervisorZonesService interface {
 	List(context.Context, *ListOptions) ([]HypervisorZone, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]HypervisorZone, *Response, error)
 	Get(context.Context, int) (*HypervisorZone, *Response, error)
 	// Delete(context.Context, int) (*Response, error)
 	Delete(context.Context, int, interface{}) (*Transaction, *Response, error)
@@ -29,6 +26,8 @@ type HypervisorZonesServiceOp struct {
 
 var _ HypervisorZonesService = &HypervisorZonesServiceOp{}
 
+var hypervisorZonesCRUD = crud[HypervisorZone]{name: "HypervisorZone", path: hypervisorZonesBasePath, root: "hypervisor_zone"}
+
 // Certificate -
 type Certificate struct {
 	ExpireAt string `json:"expire_at,omitempty"`
@@ -134,88 +133,27 @@ type HypervisorZone struct {
 	UserVirtualServerPricing UserVirtualServerPricing `json:"user_virtual_server_pricing,omitempty"`
 }
 
-type hypervisorZonesRoot struct {
-	HypervisorZone *HypervisorZone `json:"hypervisor_zone"`
-}
-
 // List all HypervisorZones.
 func (s *HypervisorZonesServiceOp) List(ctx context.Context, opt *ListOptions) ([]HypervisorZone, *Response, error) {
-	path := hypervisorZonesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]HypervisorZone
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]HypervisorZone, len(out))
-	for i := range arr {
-		arr[i] = out[i]["hypervisor_zone"]
-	}
+	return hypervisorZonesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of HypervisorZones.
+func (s *HypervisorZonesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]HypervisorZone, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual HypervisorZone.
 func (s *HypervisorZonesServiceOp) Get(ctx context.Context, id int) (*HypervisorZone, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorZonesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(hypervisorZonesRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.HypervisorZone, resp, err
+	return hypervisorZonesCRUD.get(ctx, s.client, id)
 }
 
 // Delete HypervisorZone.
 func (s *HypervisorZonesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Transaction, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorZonesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	filter := struct {
-		AssociatedObjectID   int
-		AssociatedObjectType string
-	}{
+	filter := &TransactionFilter{
 		AssociatedObjectID:   id,
 		AssociatedObjectType: "HypervisorZone",
 	}
 
-	return lastTransaction(ctx, s.client, filter)
+	return hypervisorZonesCRUD.deleteTransaction(ctx, s.client, id, meta, filter)
 }
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -30,6 +27,8 @@ type FirewallRulesServiceOp struct {
 
 var _ FirewallRulesService = &FirewallRulesServiceOp{}
 
+var firewallRulesCRUD = crud[FirewallRule]{name: "FirewallRule", path: firewallRulesBasePath, root: "firewall_rule"}
+
 // FirewallRule -
 // https://docs.onapp.com/apim/latest/firewall-rules-for-vss
 type FirewallRule struct {
@@ -63,65 +62,22 @@ type FirewallRuleCreateRequest struct {
 	Port               string `json:"port,omitempty"`
 }
 
-type firewallRuleCreateRequestRoot struct {
-	FirewallRuleCreateRequest *FirewallRuleCreateRequest `json:"firewall_rule"`
-}
-
-type firewallRuleRoot struct {
-	FirewallRule *FirewallRule `json:"firewall_rule"`
-}
-
 func (d FirewallRuleCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all FirewallRules
 func (s *FirewallRulesServiceOp) List(ctx context.Context, vmID int, opt *ListOptions) ([]FirewallRule, *Response, error) {
-	path := fmt.Sprintf(firewallRulesBasePath, vmID) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]FirewallRule
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]FirewallRule, len(out))
-	for i := range arr {
-		arr[i] = out[i]["firewall_rule"]
-	}
-
-	return arr, resp, err
+	return firewallRulesCRUD.at(vmID).list(ctx, s.client, opt)
 }
 
 // Get individual FirewallRule
 func (s *FirewallRulesServiceOp) Get(ctx context.Context, vmID int, id int) (*FirewallRule, *Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(firewallRulesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(firewallRuleRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+	if vmID < 1 {
+		return nil, nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
 
-	return root.FirewallRule, resp, err
+	return firewallRulesCRUD.at(vmID).get(ctx, s.client, id)
 }
 
 // Create FirewallRule
@@ -130,70 +86,23 @@ func (s *FirewallRulesServiceOp) Create(ctx context.Context, vmID int, createReq
 		return nil, nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
 
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("FirewallRule createRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(firewallRulesBasePath, vmID) + apiFormat
-	rootRequest := &firewallRuleCreateRequestRoot{
-		FirewallRuleCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("FirewallRule [Create] req: ", req)
-
-	root := new(firewallRuleRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.FirewallRule, resp, err
+	return firewallRulesCRUD.at(vmID).create(ctx, s.client, createRequest)
 }
 
 // Delete FirewallRule
 func (s *FirewallRulesServiceOp) Delete(ctx context.Context, vmID int, id int, meta interface{}) (*Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(firewallRulesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
+	if vmID < 1 {
+		return nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
-	fmt.Println("FirewallRule [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return firewallRulesCRUD.at(vmID).delete(ctx, s.client, id, meta)
 }
 
 // Edit FirewallRule
 func (s *FirewallRulesServiceOp) Edit(ctx context.Context, vmID int, id int, editRequest *FirewallRuleCreateRequest) (*Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("FirewallRule [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(firewallRulesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
+	if vmID < 1 {
+		return nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
-	log.Println("FirewallRule [Edit]  req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return firewallRulesCRUD.at(vmID).edit(ctx, s.client, id, editRequest)
 }
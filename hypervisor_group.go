@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -19,6 +18,7 @@ const listOfAttachedComputeResources string = "settings/hypervisor_zones/%d/hype
 // See: https://docs.onapp.com/apim/latest/compute-zones
 type HypervisorGroupsService interface {
 	List(context.Context, *ListOptions) ([]HypervisorGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]HypervisorGroup, *Response, error)
 	Get(context.Context, int) (*HypervisorGroup, *Response, error)
 	Create(context.Context, *HypervisorGroupCreateRequest) (*HypervisorGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -35,6 +35,8 @@ type HypervisorGroupsServiceOp struct {
 
 var _ HypervisorGroupsService = &HypervisorGroupsServiceOp{}
 
+var hypervisorGroupsCRUD = crud[HypervisorGroup]{name: "HypervisorGroup", path: hypervisorGroupsBasePath, root: "hypervisor_group"}
+
 // HypervisorGroup represent Compute Zone of the OnApp API
 type HypervisorGroup struct {
 	AdditionalFields            []AdditionalFields `json:"additional_fields,omitempty"`
@@ -114,134 +116,38 @@ type HypervisorGroupEditRequest struct {
 	СPUGuarantee        int    `json:"cpu_guarantee,omitempty"`
 }
 
-type hypervisorGroupCreateRequestRoot struct {
-	HypervisorGroupCreateRequest *HypervisorGroupCreateRequest `json:"hypervisor_group"`
-}
-
-type hypervisorGroupRoot struct {
-	HypervisorGroup *HypervisorGroup `json:"hypervisor_group"`
-}
-
 func (d HypervisorGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all HypervisorGroup.
 func (s *HypervisorGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]HypervisorGroup, *Response, error) {
-	path := hypervisorGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]HypervisorGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]HypervisorGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["hypervisor_group"]
-	}
+	return hypervisorGroupsCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of HypervisorGroups.
+func (s *HypervisorGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]HypervisorGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual HypervisorGroup.
 func (s *HypervisorGroupsServiceOp) Get(ctx context.Context, id int) (*HypervisorGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(hypervisorGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.HypervisorGroup, resp, err
+	return hypervisorGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create HypervisorGroup.
 func (s *HypervisorGroupsServiceOp) Create(ctx context.Context, createRequest *HypervisorGroupCreateRequest) (*HypervisorGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("HypervisorZone createRequest", "cannot be nil")
-	}
-
-	path := hypervisorGroupsBasePath + apiFormat
-
-	rootRequest := &hypervisorGroupCreateRequestRoot{
-		HypervisorGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("HypervisorGroup [Create] req: ", req)
-
-	root := new(hypervisorGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.HypervisorGroup, resp, err
+	return hypervisorGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete HypervisorGroup.
 func (s *HypervisorGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorGroupsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("HypervisorGroup [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return hypervisorGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit HypervisorGroup
 func (s *HypervisorGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *HypervisorGroupEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("HypervisorZone [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("HypervisorGroup [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return hypervisorGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 // ListOfAttachedComputeResources -
@@ -255,7 +161,7 @@ func (s *HypervisorGroupsServiceOp) ListOfAttachedComputeResources(ctx context.C
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("HypervisorGroup [ListOfAttachedComputeResources]  req: ", req)
+	s.client.debugRequest("HypervisorGroup [ListOfAttachedComputeResources]", req)
 
 	var out []map[string]Hypervisor
 	resp, err := s.client.Do(ctx, req, &out)
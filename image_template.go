@@ -2,11 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
-
-	"github.com/digitalocean/godo"
 )
 
 const imageTemplatesBasePath string = "templates"
@@ -18,6 +13,7 @@ const imageTemplatesBasePath string = "templates"
 // Describe templates *installed* on the OnApp cloud
 type ImageTemplatesService interface {
 	List(context.Context, *ListOptions) ([]ImageTemplate, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]ImageTemplate, *Response, error)
 	Get(context.Context, int) (*ImageTemplate, *Response, error)
 	Create(context.Context, *ImageTemplateCreateRequest) (*ImageTemplate, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -32,6 +28,8 @@ type ImageTemplatesServiceOp struct {
 
 var _ ImageTemplatesService = &ImageTemplatesServiceOp{}
 
+var imageTemplatesCRUD = crud[ImageTemplate]{name: "ImageTemplate", path: imageTemplatesBasePath, root: "image_template"}
+
 // ImageTemplate - represent a template of OnApp API from cloud
 type ImageTemplate struct {
 	AllowResizeWithoutReboot  bool                              `json:"allow_resize_without_reboot,bool"`
@@ -83,10 +81,6 @@ type ImageTemplateCreateRequest struct {
 	BackupServerID string `json:"backup_server_id"` // don't use omitempty because BackupServerID can be empty
 }
 
-type imageTemplateCreateRequestRoot struct {
-	ImageTemplateCreateRequest *ImageTemplateCreateRequest `json:"image_template"`
-}
-
 // ImageTemplateEditRequest represents a request to edit template
 type ImageTemplateEditRequest struct {
 	Label             string `json:"label,omitempty"`
@@ -97,129 +91,32 @@ type ImageTemplateEditRequest struct {
 	AllowedHotMigrate bool   `json:"allowed_hot_migrate,bool"`
 }
 
-type imageTemplateEditRequestRoot struct {
-	ImageTemplateEditRequest *ImageTemplateEditRequest `json:"image_template"`
-}
-
-type imageTemplatesRoot struct {
-	ImageTemplate *ImageTemplate `json:"image_template"`
-}
-
 // List all ImageTemplates.
 func (s *ImageTemplatesServiceOp) List(ctx context.Context, opt *ListOptions) ([]ImageTemplate, *Response, error) {
-	path := imageTemplatesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("ImageTemplate [List] req: ", req)
-
-	var out []map[string]ImageTemplate
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]ImageTemplate, len(out))
-	for i := range arr {
-		arr[i] = out[i]["image_template"]
-	}
+	return imageTemplatesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of ImageTemplates.
+func (s *ImageTemplatesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]ImageTemplate, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual ImageTemplate.
 func (s *ImageTemplatesServiceOp) Get(ctx context.Context, id int) (*ImageTemplate, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplatesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("ImageTemplate [Get] req: ", req)
-
-	root := new(imageTemplatesRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.ImageTemplate, resp, err
+	return imageTemplatesCRUD.get(ctx, s.client, id)
 }
 
 // Create ImageTemplate.
 func (s *ImageTemplatesServiceOp) Create(ctx context.Context, createRequest *ImageTemplateCreateRequest) (*ImageTemplate, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("ImageTemplate createRequest", "cannot be nil")
-	}
-
-	path := imageTemplatesBasePath + apiFormat
-	rootRequest := &imageTemplateCreateRequestRoot{
-		ImageTemplateCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("ImageTemplate [Create] req: ", req)
-
-	root := new(imageTemplatesRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.ImageTemplate, resp, err
+	return imageTemplatesCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete ImageTemplate.
 func (s *ImageTemplatesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplatesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("ImageTemplate [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return imageTemplatesCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit ImageTemplate
 func (s *ImageTemplatesServiceOp) Edit(ctx context.Context, id int, editRequest *ImageTemplateEditRequest) (*Response, error) {
-	if editRequest == nil {
-		return nil, godo.NewArgError("ImageTemplate editRequest", "cannot be nil")
-	}
-
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplatesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("ImageTemplate [Edit] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return imageTemplatesCRUD.edit(ctx, s.client, id, editRequest)
 }
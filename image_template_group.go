@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -17,6 +16,7 @@ const attachDetachTemplateBasePath string = imageTemplateGroupsBasePath + "/%d/r
 // https://docs.onapp.com/apim/latest/template-store
 type ImageTemplateGroupsService interface {
 	List(context.Context, *ListOptions) ([]ImageTemplateGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]ImageTemplateGroup, *Response, error)
 	Get(context.Context, int) (*ImageTemplateGroup, *Response, error)
 	Create(context.Context, *ImageTemplateGroupCreateRequest) (*ImageTemplateGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -34,6 +34,8 @@ type ImageTemplateGroupsServiceOp struct {
 
 var _ ImageTemplateGroupsService = &ImageTemplateGroupsServiceOp{}
 
+var imageTemplateGroupsCRUD = crud[ImageTemplateGroup]{name: "ImageTemplateGroup", path: imageTemplateGroupsBasePath, root: "image_template_group"}
+
 // ImageTemplateGroup - represent a template of OnApp API
 type ImageTemplateGroup struct {
 	CreatedAt         string `json:"created_at,omitempty"`
@@ -78,10 +80,6 @@ type ImageTemplateGroupEditRequest struct {
 	Own            bool   `json:"own,bool"`
 }
 
-type imageTemplateGroupCreateRequestRoot struct {
-	ImageTemplateGroupCreateRequest *ImageTemplateGroupCreateRequest `json:"image_template_group"`
-}
-
 type imageTemplateGroupsRoot struct {
 	ImageTemplateGroup *ImageTemplateGroup `json:"image_template_group"`
 }
@@ -99,119 +97,32 @@ type imageTemplateGroupAttachRequestRoot struct {
 
 // List all ImageTemplateGroups.
 func (s *ImageTemplateGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]ImageTemplateGroup, *Response, error) {
-	path := imageTemplateGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]ImageTemplateGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]ImageTemplateGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["image_template_group"]
-	}
+	return imageTemplateGroupsCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of ImageTemplateGroups.
+func (s *ImageTemplateGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]ImageTemplateGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual ImageTemplateGroup.
 func (s *ImageTemplateGroupsServiceOp) Get(ctx context.Context, id int) (*ImageTemplateGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplateGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(imageTemplateGroupsRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.ImageTemplateGroup, resp, err
+	return imageTemplateGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create ImageTemplateGroup.
 func (s *ImageTemplateGroupsServiceOp) Create(ctx context.Context, createRequest *ImageTemplateGroupCreateRequest) (*ImageTemplateGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("ImageTemplateGroup createRequest", "cannot be nil")
-	}
-
-	path := imageTemplateGroupsBasePath + apiFormat
-	rootRequest := &imageTemplateGroupCreateRequestRoot{
-		ImageTemplateGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("ImageTemplateGroup [Create] req: ", req)
-
-	root := new(imageTemplateGroupsRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.ImageTemplateGroup, resp, err
+	return imageTemplateGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete ImageTemplateGroup.
 func (s *ImageTemplateGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplateGroupsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("ImageTemplateGroup [Delete]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return imageTemplateGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit ImageTemplateGroup.
 func (s *ImageTemplateGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *ImageTemplateGroupEditRequest) (*Response, error) {
-	if editRequest == nil {
-		return nil, godo.NewArgError("ImageTemplateGroup editRequest", "cannot be nil")
-	}
-
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", imageTemplateGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("ImageTemplateGroup [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return imageTemplateGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 // Attach template to the ImageTemplateGroup.
@@ -233,7 +144,7 @@ func (s *ImageTemplateGroupsServiceOp) Attach(ctx context.Context, groupID int,
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("ImageTemplateGroup [Attach] req: ", req)
+	s.client.debugRequest("ImageTemplateGroup [Attach]", req)
 
 	root := new(imageTemplateGroupsRoot)
 	resp, err := s.client.Do(ctx, req, root)
@@ -257,7 +168,7 @@ func (s *ImageTemplateGroupsServiceOp) Detach(ctx context.Context, groupID int,
 	if err != nil {
 		return nil, err
 	}
-	log.Println("ImageTemplateGroup [Detach] req: ", req)
+	s.client.debugRequest("ImageTemplateGroup [Detach]", req)
 
 	root := new(imageTemplateGroupsRoot)
 	resp, err := s.client.Do(ctx, req, root)
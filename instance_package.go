@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const instancePackagesBasePath string = "instance_packages"
 // https://docs.onapp.com/apim/latest/instance-packages
 type InstancePackagesService interface {
 	List(context.Context, *ListOptions) ([]InstancePackage, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]InstancePackage, *Response, error)
 	Get(context.Context, int) (*InstancePackage, *Response, error)
 	Create(context.Context, *InstancePackageCreateRequest) (*InstancePackage, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type InstancePackagesServiceOp struct {
 
 var _ InstancePackagesService = &InstancePackagesServiceOp{}
 
+var instancePackagesCRUD = crud[InstancePackage]{name: "InstancePackage", path: instancePackagesBasePath, root: "instance_package"}
+
 // InstancePackage represents a InstancePackage
 type InstancePackage struct {
 	Bandwidth   int    `json:"bandwidth,omitempty"`
@@ -55,130 +55,36 @@ type InstancePackageCreateRequest struct {
 
 type InstancePackageEditRequest InstancePackageCreateRequest
 
-type instancePackageCreateRequestRoot struct {
-	InstancePackageCreateRequest *InstancePackageCreateRequest `json:"instance_package"`
-}
-
-type instancePackageRoot struct {
-	InstancePackage *InstancePackage `json:"instance_package"`
-}
-
 func (d InstancePackageCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all DataStoreGroups.
 func (s *InstancePackagesServiceOp) List(ctx context.Context, opt *ListOptions) ([]InstancePackage, *Response, error) {
-	path := instancePackagesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]InstancePackage
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]InstancePackage, len(out))
-	for i := range arr {
-		arr[i] = out[i]["instance_package"]
-	}
-
-	return arr, resp, err
+	return instancePackagesCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of InstancePackages.
+func (s *InstancePackagesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]InstancePackage, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual InstancePackage.
 func (s *InstancePackagesServiceOp) Get(ctx context.Context, id int) (*InstancePackage, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", instancePackagesBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(instancePackageRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.InstancePackage, resp, err
+	return instancePackagesCRUD.get(ctx, s.client, id)
 }
 
 // Create InstancePackage.
 func (s *InstancePackagesServiceOp) Create(ctx context.Context, createRequest *InstancePackageCreateRequest) (*InstancePackage, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("InstancePackage createRequest", "cannot be nil")
-	}
-
-	path := instancePackagesBasePath + apiFormat
-	rootRequest := &instancePackageCreateRequestRoot{
-		InstancePackageCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("InstancePackage [Create] req: ", req)
-
-	root := new(instancePackageRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.InstancePackage, resp, err
+	return instancePackagesCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete InstancePackage.
 func (s *InstancePackagesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", instancePackagesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("InstancePackage [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return instancePackagesCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit InstancePackage.
 func (s *InstancePackagesServiceOp) Edit(ctx context.Context, id int, editRequest *InstancePackageEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("InstancePackage editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", instancePackagesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("InstancePackage [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return instancePackagesCRUD.edit(ctx, s.client, id, editRequest)
 }
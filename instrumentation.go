@@ -0,0 +1,230 @@
+package onappgo
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the metrics recorded for every API call.
+const (
+	// MetricRequestsTotal counts API calls by service, method and status
+	MetricRequestsTotal = "onapp_api_requests_total"
+
+	// MetricRequestDuration observes API call latency in seconds by service and method
+	MetricRequestDuration = "onapp_api_request_duration_seconds"
+)
+
+// Span attribute keys, named after the OpenTelemetry HTTP semantic conventions.
+const (
+	AttributeHTTPMethod     = "http.method"
+	AttributeHTTPRoute      = "http.route"
+	AttributeHTTPStatusCode = "http.status_code"
+	AttributeRequestID      = "onapp.request_id"
+	AttributeService        = "onapp.service"
+)
+
+// namespaces are path prefixes which group several services
+var namespaces = map[string]bool{
+	"billing":        true,
+	"settings":       true,
+	"sysadmin_tools": true,
+}
+
+var numericSegment = regexp.MustCompile(`^[0-9]+$`)
+
+// Tracer starts a span for every API call. An OpenTelemetry trace.Tracer can
+// be plugged in with a thin adapter around its Start method.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced API call.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Metrics receives Prometheus style counters and histograms, a CounterVec or
+// HistogramVec can be selected by name and fed with the labels.
+type Metrics interface {
+	IncCounter(name string, labels map[string]string)
+	ObserveHistogram(name string, value float64, labels map[string]string)
+}
+
+// SetTracer is a client option for tracing every API call.
+func SetTracer(t Tracer) ClientOpt {
+	return func(c *Client) error {
+		c.tracer = t
+		return nil
+	}
+}
+
+// SetMetrics is a client option for recording metrics of every API call.
+func SetMetrics(m Metrics) ClientOpt {
+	return func(c *Client) error {
+		c.metrics = m
+		return nil
+	}
+}
+
+// instrument starts the span of the request and returns the function which
+// finishes the span and records the metrics once the call is done.
+func (c *Client) instrument(ctx context.Context, req *http.Request) (context.Context, func(*Response, error)) {
+	if c.tracer == nil && c.metrics == nil {
+		return ctx, func(*Response, error) {}
+	}
+
+	route := c.templatedPath(req)
+	service := serviceName(route)
+	start := time.Now()
+
+	var span Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, req.Method+" "+route)
+		span.SetAttribute(AttributeHTTPMethod, req.Method)
+		span.SetAttribute(AttributeHTTPRoute, route)
+		span.SetAttribute(AttributeService, service)
+	}
+
+	return ctx, func(resp *Response, err error) {
+		status := "error"
+		if resp != nil && resp.Response != nil {
+			status = strconv.Itoa(resp.StatusCode)
+		}
+
+		if span != nil {
+			if resp != nil && resp.Response != nil {
+				span.SetAttribute(AttributeHTTPStatusCode, resp.StatusCode)
+				if resp.RequestID != "" {
+					span.SetAttribute(AttributeRequestID, resp.RequestID)
+				}
+			}
+
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}
+
+		if c.metrics != nil {
+			c.metrics.IncCounter(MetricRequestsTotal, map[string]string{
+				"service": service,
+				"method":  req.Method,
+				"status":  status,
+			})
+			c.metrics.ObserveHistogram(MetricRequestDuration, time.Since(start).Seconds(), map[string]string{
+				"service": service,
+				"method":  req.Method,
+			})
+		}
+	}
+}
+
+// templatedPath returns the request path relative to BaseURL without format
+// suffix and with numeric IDs replaced, e.g. virtual_machines/{id}/startup.
+func (c *Client) templatedPath(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+	path = strings.TrimSuffix(strings.Trim(path, "/"), apiFormat)
+
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if numericSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// relativePath returns the request path relative to the base URL with the query.
+func (c *Client) relativePath(req *http.Request) string {
+	path := "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, c.BaseURL.Path), "/")
+	if req.URL.RawQuery != "" {
+		path += "?" + req.URL.RawQuery
+	}
+
+	return path
+}
+
+// serviceName returns the top level resource of the templated path.
+func serviceName(route string) string {
+	segments := strings.Split(route, "/")
+	if len(segments) > 1 && namespaces[segments[0]] {
+		return segments[1]
+	}
+
+	return segments[0]
+}
+
+// MemoryMetrics is a Metrics implementation which keeps everything in memory,
+// mostly useful in tests.
+type MemoryMetrics struct {
+	mu         sync.Mutex
+	counters   map[string]float64
+	histograms map[string][]float64
+}
+
+// NewMemoryMetrics returns an empty MemoryMetrics.
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{
+		counters:   make(map[string]float64),
+		histograms: make(map[string][]float64),
+	}
+}
+
+// IncCounter increments the counter with the given labels.
+func (m *MemoryMetrics) IncCounter(name string, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counters[metricKey(name, labels)]++
+}
+
+// ObserveHistogram records the value for the histogram with the given labels.
+func (m *MemoryMetrics) ObserveHistogram(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := metricKey(name, labels)
+	m.histograms[key] = append(m.histograms[key], value)
+}
+
+// Counter returns the current value of the counter with the given labels.
+func (m *MemoryMetrics) Counter(name string, labels map[string]string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.counters[metricKey(name, labels)]
+}
+
+// Observations returns the values recorded for the histogram with the given labels.
+func (m *MemoryMetrics) Observations(name string, labels map[string]string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]float64(nil), m.histograms[metricKey(name, labels)]...)
+}
+
+// metricKey formats the metric in the Prometheus exposition style,
+// e.g. name{a="1",b="2"}
+func metricKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + strconv.Quote(labels[k])
+	}
+
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,68 @@
+package onappgo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestDo_instrumentation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tracer := &testTracer{}
+	metrics := NewMemoryMetrics()
+	require.NoError(t, SetTracer(tracer)(client))
+	require.NoError(t, SetMetrics(metrics)(client))
+
+	mux.HandleFunc("/virtual_machines/12/startup.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRequestID, "req-12")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "virtual_machines/12/startup.json", nil)
+	_, err := client.Do(ctx, req, nil)
+	require.Error(t, err)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	require.Equal(t, "POST virtual_machines/{id}/startup", span.name)
+	require.Equal(t, "virtual_machines/{id}/startup", span.attributes[AttributeHTTPRoute])
+	require.Equal(t, http.StatusUnprocessableEntity, span.attributes[AttributeHTTPStatusCode])
+	require.Equal(t, "req-12", span.attributes[AttributeRequestID])
+	require.Equal(t, err, span.err)
+	require.True(t, span.ended)
+
+	labels := map[string]string{"service": "virtual_machines", "method": http.MethodPost, "status": "422"}
+	require.Equal(t, float64(1), metrics.Counter(MetricRequestsTotal, labels))
+	require.Len(t, metrics.Observations(MetricRequestDuration, map[string]string{"service": "virtual_machines", "method": http.MethodPost}), 1)
+}
+
+func TestServiceName(t *testing.T) {
+	require.Equal(t, "disks", serviceName("settings/disks/{id}"))
+	require.Equal(t, "users", serviceName("users/{id}/user_white_lists"))
+	require.Equal(t, "buckets", serviceName("billing/buckets/{id}/access_controls"))
+}
@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -35,6 +34,8 @@ type IntegratedDataStoresServiceOp struct {
 
 var _ IntegratedDataStoresService = &IntegratedDataStoresServiceOp{}
 
+var integratedDataStoresCRUD = crud[IntegratedDataStores]{name: "IntegratedDataStores", path: integratedDataStoresBasePath, root: "data_store"}
+
 type Node struct {
 	ID string `json:"id,omitempty"`
 }
@@ -82,14 +83,6 @@ type IntegratedDataStoreCreateRequest struct {
 // IntegratedDataStoresEditRequest represents a request to edit a IntegrateDataStores
 type IntegratedDataStoresEditRequest IntegratedDataStoreCreateRequest
 
-type integratedDataStoreCreateRequestRoot struct {
-	IntegratedDataStoreCreateRequest *IntegratedDataStoreCreateRequest `json:"storage_data_store"`
-}
-
-type integratedDataStoreRoot struct {
-	IntegratedDataStores *IntegratedDataStores `json:"data_store"`
-}
-
 func (d IntegratedDataStoreCreateRequest) String() string {
 	return godo.Stringify(d)
 }
@@ -100,30 +93,7 @@ func (s *IntegratedDataStoresServiceOp) List(ctx context.Context, resID int, opt
 		return nil, nil, godo.NewArgError("resID", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(integratedDataStoresBasePath, resID) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("IntegratedDataStores [List]  req: ", req)
-
-	var out []map[string]IntegratedDataStores
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]IntegratedDataStores, len(out))
-	for i := range arr {
-		arr[i] = out[i]["data_store"]
-	}
-
-	return arr, resp, err
+	return integratedDataStoresCRUD.at(resID).list(ctx, s.client, opt)
 }
 
 // Get individual
@@ -132,50 +102,26 @@ func (s *IntegratedDataStoresServiceOp) Get(ctx context.Context, resID int, id s
 		return nil, nil, godo.NewArgError("resID or id", "cannot be empty or less than 1")
 	}
 
-	path := fmt.Sprintf(integratedDataStoresBasePath, resID)
-	path = fmt.Sprintf("%s/%s%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Println("IntegratedDataStores [Get]  req: ", req)
-
-	root := new(integratedDataStoreRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.IntegratedDataStores, resp, err
+	r := integratedDataStoresCRUD.at(resID)
+	return r.do(ctx, s.client, "Get", http.MethodGet, r.memberKey(id), nil)
 }
 
 // Create -
 func (s *IntegratedDataStoresServiceOp) Create(ctx context.Context, resID int, createRequest *IntegratedDataStoreCreateRequest) (*IntegratedDataStores, *Response, error) {
-	if resID < 1 || createRequest == nil {
-		return nil, nil, godo.NewArgError("IntegratedDataStores createRequest", "cannot be nil")
+	if resID < 1 {
+		return nil, nil, godo.NewArgError("resID", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(integratedDataStoresBasePath, resID) + apiFormat
-	rootRequest := &integratedDataStoreCreateRequestRoot{
-		IntegratedDataStoreCreateRequest: createRequest,
+	if createRequest == nil {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("IntegratedDataStores [Create] req: ", req)
-
-	root := new(integratedDataStoreRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
+	r := integratedDataStoresCRUD.at(resID)
+	rootRequest := wrapResource("storage_data_store", createRequest)
 
 	// action	"plug_hardware_disk_device"
 
-	return root.IntegratedDataStores, resp, err
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), rootRequest)
 }
 
 // Delete -
@@ -183,21 +129,14 @@ func (s *IntegratedDataStoresServiceOp) Delete(ctx context.Context, resID int, i
 	if resID < 1 || id == "" {
 		return nil, godo.NewArgError("resID or id", "cannot be empty or less than 1")
 	}
-	path := fmt.Sprintf(integratedDataStoresBasePath, resID)
-	path = fmt.Sprintf("%s/%s%s", path, id, apiFormat)
-
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	r := integratedDataStoresCRUD.at(resID)
+	path, err := addOptions(r.memberKey(id), meta)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("IntegratedDataStores [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.send(ctx, s.client, "Delete", http.MethodDelete, path, nil)
 }
 
 // Edit -
@@ -207,19 +146,11 @@ func (s *IntegratedDataStoresServiceOp) Edit(ctx context.Context, resID int, id
 	}
 
 	if editRequest == nil {
-		return nil, godo.NewArgError("IntegratedDataStores [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(integratedDataStoresBasePath, resID)
-	path = fmt.Sprintf("%s/%s%s", path, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
+		return nil, godo.NewArgError("editRequest", "cannot be nil")
 	}
-	log.Println("IntegratedDataStores [Edit]  req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	r := integratedDataStoresCRUD.at(resID)
+	return r.send(ctx, s.client, "Edit", http.MethodPut, r.memberKey(id), editRequest)
 }
 
 // StorageNodes - get list of storage nodes from computer zone
@@ -234,7 +165,7 @@ func (s *IntegratedDataStoresServiceOp) StorageNodes(ctx context.Context, hvgID
 		return nil, nil, err
 	}
 
-	log.Println("IntegratedDataStores [StorageNodes]  req: ", req)
+	s.client.debugRequest("IntegratedDataStores [StorageNodes]", req)
 
 	root := &StorageNodes{}
 	resp, err := s.client.Do(ctx, req, root)
@@ -257,7 +188,7 @@ func (s *IntegratedDataStoresServiceOp) BackendNodes(ctx context.Context, hvgID
 		return nil, nil, err
 	}
 
-	log.Println("IntegratedDataStores [BackendNodes]  req: ", req)
+	s.client.debugRequest("IntegratedDataStores [BackendNodes]", req)
 
 	root := &BackendNodes{}
 	resp, err := s.client.Do(ctx, req, root)
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -30,6 +27,8 @@ type IPNetsServiceOp struct {
 
 var _ IPNetsService = &IPNetsServiceOp{}
 
+var ipNetsCRUD = crud[IPNet]{name: "IPNet", path: ipNetsBasePath, root: "ip_net"}
+
 // ID -
 type ID struct {
 	ID int `json:"id"`
@@ -69,14 +68,6 @@ type IPNetEditRequest struct {
 	NetworkMask         int    `json:"network_mask,omitempty"`
 }
 
-type ipNetCreateRequestRoot struct {
-	IPNetCreateRequest *IPNetCreateRequest `json:"ip_net"`
-}
-
-type ipNetRoot struct {
-	IPNet *IPNet `json:"ip_net"`
-}
-
 func (d IPNetCreateRequest) String() string {
 	return godo.Stringify(d)
 }
@@ -84,126 +75,36 @@ func (d IPNetCreateRequest) String() string {
 // List all IPNet
 func (s *IPNetsServiceOp) List(ctx context.Context, net int, opt *ListOptions) ([]IPNet, *Response, error) {
 	if net < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(ipNetsBasePath, net) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]IPNet
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]IPNet, len(out))
-	for i := range arr {
-		arr[i] = out[i]["ip_net"]
+		return nil, nil, godo.NewArgError("net", "cannot be less than 1")
 	}
 
-	return arr, resp, err
+	return ipNetsCRUD.at(net).list(ctx, s.client, opt)
 }
 
 // Get individual IPNet
 func (s *IPNetsServiceOp) Get(ctx context.Context, net int, id int) (*IPNet, *Response, error) {
-	if net < 1 || id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(ipNetsBasePath, net)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(ipNetRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+	if net < 1 {
+		return nil, nil, godo.NewArgError("net", "cannot be less than 1")
 	}
 
-	return root.IPNet, resp, err
+	return ipNetsCRUD.at(net).get(ctx, s.client, id)
 }
 
 // Create IPNet
 func (s *IPNetsServiceOp) Create(ctx context.Context, net int, createRequest *IPNetCreateRequest) (*IPNet, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("IPNet createRequest", "cannot be nil")
-	}
-
 	if net < 1 {
 		return nil, nil, godo.NewArgError("net", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(ipNetsBasePath, net) + apiFormat
-	rootRequest := &ipNetCreateRequestRoot{
-		IPNetCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("IPNet [Create] req: ", req)
-
-	root := new(ipNetRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.IPNet, resp, err
+	return ipNetsCRUD.at(net).create(ctx, s.client, createRequest)
 }
 
 // Delete IPNet
 func (s *IPNetsServiceOp) Delete(ctx context.Context, net int, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(ipNetsBasePath, net)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("IPNet [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return ipNetsCRUD.at(net).delete(ctx, s.client, id, meta)
 }
 
 // Edit IPNet
 func (s *IPNetsServiceOp) Edit(ctx context.Context, net int, id int, editRequest *IPNetEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("IPNet [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(ipNetsBasePath, net)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("IPNet [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return ipNetsCRUD.at(net).edit(ctx, s.client, id, editRequest)
 }
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -30,6 +27,8 @@ type IPRangesServiceOp struct {
 
 var _ IPRangesService = &IPRangesServiceOp{}
 
+var ipRangesCRUD = crud[IPRange]{name: "IPRange", path: ipRangesBasePath, root: "ip_range"}
+
 // IPRange -
 type IPRange struct {
 	ID                  int    `json:"id,omitempty"`
@@ -52,137 +51,39 @@ type IPRangeCreateRequest struct {
 	GatewayOutsideIPNet bool   `json:"gateway_outside_ip_net,bool"`
 }
 
-type ipRangeCreateRequestRoot struct {
-	IPRangeCreateRequest *IPRangeCreateRequest `json:"ip_range"`
-}
-
-type ipRangeRoot struct {
-	IPRange *IPRange `json:"ip_range"`
-}
-
 func (d IPRangeCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all IPRanges.
 func (s *IPRangesServiceOp) List(ctx context.Context, net int, ipnet int, opt *ListOptions) ([]IPRange, *Response, error) {
-	path := fmt.Sprintf(ipRangesBasePath, net, ipnet) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]IPRange
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]IPRange, len(out))
-	for i := range arr {
-		arr[i] = out[i]["ip_range"]
-	}
-
-	return arr, resp, err
+	return ipRangesCRUD.at(net, ipnet).list(ctx, s.client, opt)
 }
 
 // Get individual IPRange.
 func (s *IPRangesServiceOp) Get(ctx context.Context, net int, ipnet int, id int) (*IPRange, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(ipRangesBasePath, net, ipnet)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(ipRangeRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.IPRange, resp, err
+	return ipRangesCRUD.at(net, ipnet).get(ctx, s.client, id)
 }
 
 // Create IPRange.
 func (s *IPRangesServiceOp) Create(ctx context.Context, net int, ipnet int, createRequest *IPRangeCreateRequest) (*IPRange, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("Network createRequest", "cannot be nil")
-	}
-
 	if net < 1 || ipnet < 1 {
 		return nil, nil, godo.NewArgError("net || ipnet", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(ipRangesBasePath, net, ipnet)
-	rootRequest := &ipRangeCreateRequestRoot{
-		IPRangeCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("IPRange [Create] req: ", req)
-
-	root := new(ipRangeRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.IPRange, resp, err
+	return ipRangesCRUD.at(net, ipnet).create(ctx, s.client, createRequest)
 }
 
 // Delete IPRange.
 func (s *IPRangesServiceOp) Delete(ctx context.Context, net int, ipnet int, id int, meta interface{}) (*Response, error) {
-	if net < 1 || ipnet < 1 || id < 1 {
-		return nil, godo.NewArgError("net || ipnet || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(ipRangesBasePath, net, ipnet)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
+	if net < 1 || ipnet < 1 {
+		return nil, godo.NewArgError("net || ipnet", "cannot be less than 1")
 	}
-	log.Println("IPRange [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return ipRangesCRUD.at(net, ipnet).delete(ctx, s.client, id, meta)
 }
 
 // Edit IPRange
 func (s *IPRangesServiceOp) Edit(ctx context.Context, net int, ipnet int, id int, editRequest *IPRangeCreateRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("IPRange [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(ipRangesBasePath, net, ipnet)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("IPRange [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return ipRangesCRUD.at(net, ipnet).edit(ctx, s.client, id, editRequest)
 }
@@ -2,7 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -27,6 +26,8 @@ type LicensesServiceOp struct {
 
 var _ LicensesService = &LicensesServiceOp{}
 
+var licensesCRUD = crud[License]{name: "License", path: licensesBasePath, root: "license"}
+
 type License struct {
 	IntegratedStorageLimit string `json:"integrated_storage_limit,omitempty"`
 	IsolatedLicense        bool   `json:"isolated_license,bool"`
@@ -54,50 +55,22 @@ type licenseEditRequestRoot struct {
 	LicenseEditRequest *LicenseEditRequest `json:"configuration"`
 }
 
-type licenseRoot struct {
-	License *License `json:"license"`
-}
-
 // Get individual License.
 func (s *LicensesServiceOp) Get(ctx context.Context) (*License, *Response, error) {
-	path := licensesBasePath + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	log.Println("License [Get] req: ", req)
-
-	root := new(licenseRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.License, resp, err
+	return licensesCRUD.do(ctx, s.client, "Get", http.MethodGet, licensesCRUD.collection(), nil)
 }
 
 // Edit individual License.
 func (s *LicensesServiceOp) Edit(ctx context.Context, editRequest *LicenseEditRequest) (*Response, error) {
 	if editRequest == nil {
-		return nil, godo.NewArgError("License editRequest", "cannot be nil")
+		return nil, godo.NewArgError("editRequest", "cannot be nil")
 	}
 
-	path := licensesEditBasePath + apiFormat
-
 	rootRequest := &licenseEditRequestRoot{
 		LicenseEditRequest: editRequest,
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, rootRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	log.Println("License [Create/Edit] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return licensesCRUD.send(ctx, s.client, "Edit", http.MethodPut, licensesEditBasePath+apiFormat, rootRequest)
 }
 
 // IsValid check if license is valid
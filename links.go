@@ -15,5 +15,5 @@ func (l *Links) CurrentPage() (int, error) {
 
 // IsLastPage returns true if the current page is the last
 func (l *Links) IsLastPage() bool {
-	return l.CurPage == l.NumPages
+	return l.CurPage >= l.NumPages
 }
@@ -2,7 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -28,6 +27,7 @@ const locationGroupDetachBackupServerGroupBasePath string = locationGroupsBasePa
 // See: https://docs.onapp.com/apim/latest/location-groups
 type LocationGroupsService interface {
 	List(context.Context, *ListOptions) ([]LocationGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]LocationGroup, *Response, error)
 	Get(context.Context, int) (*LocationGroup, *Response, error)
 
 	Refresh(context.Context) (*Response, error)
@@ -41,6 +41,8 @@ type LocationGroupsServiceOp struct {
 
 var _ LocationGroupsService = &LocationGroupsServiceOp{}
 
+var locationGroupsCRUD = crud[LocationGroup]{name: "LocationGroup", path: locationGroupsBasePath, root: "location_group"}
+
 // LocationGroup represent LocationGroup from OnApp API
 type LocationGroup struct {
 	ID           int     `json:"id,omitempty"`
@@ -59,65 +61,23 @@ type LocationGroup struct {
 type LocationGroupCreateRequest struct {
 }
 
-type locationGroupCreateRequestRoot struct {
-	LocationGroupCreateRequest *LocationGroupCreateRequest `json:"location_group"`
-}
-
-type locationGroupRoot struct {
-	LocationGroup *LocationGroup `json:"location_group"`
-}
-
 func (d LocationGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all LocationGroups.
 func (s *LocationGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]LocationGroup, *Response, error) {
-	path := locationGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]LocationGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]LocationGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["location_group"]
-	}
+	return locationGroupsCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of LocationGroups.
+func (s *LocationGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]LocationGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual LocationGroup.
 func (s *LocationGroupsServiceOp) Get(ctx context.Context, id int) (*LocationGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", locationGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(locationGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.LocationGroup, resp, err
+	return locationGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Refresh LocationGroup.
@@ -132,7 +92,7 @@ func (s *LocationGroupsServiceOp) Refresh(ctx context.Context) (*Response, error
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("LocationGroup [Refresh] req: ", req)
+	s.client.debugRequest("LocationGroup [Refresh]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -0,0 +1,183 @@
+package onappgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Logger is the interface used for all logging of the SDK. Set it with the
+// SetLogger client option, the default logger discards everything.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// LogLevel is the minimal level of messages written by the standard logger.
+type LogLevel int
+
+const (
+	// LogLevelDebug writes all messages, including every API request
+	LogLevelDebug LogLevel = iota
+
+	// LogLevelInfo writes informational and error messages
+	LogLevelInfo
+
+	// LogLevelError writes only error messages
+	LogLevelError
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debugf(string, ...interface{}) {}
+func (noopLogger) Infof(string, ...interface{})  {}
+func (noopLogger) Errorf(string, ...interface{}) {}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger returns a Logger which writes messages of the given level and
+// above to l, or to the standard logger of the log package when l is nil.
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	if l == nil {
+		l = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+
+	return &stdLogger{logger: l, level: level}
+}
+
+func (l *stdLogger) Debugf(format string, args ...interface{}) {
+	l.logf(LogLevelDebug, "[DEBUG] "+format, args...)
+}
+
+func (l *stdLogger) Infof(format string, args ...interface{}) {
+	l.logf(LogLevelInfo, "[INFO] "+format, args...)
+}
+
+func (l *stdLogger) Errorf(format string, args ...interface{}) {
+	l.logf(LogLevelError, "[ERROR] "+format, args...)
+}
+
+func (l *stdLogger) logf(level LogLevel, format string, args ...interface{}) {
+	if level >= l.level {
+		l.logger.Printf(format, args...)
+	}
+}
+
+// SetLogger is a client option for setting the logger.
+func SetLogger(l Logger) ClientOpt {
+	return func(c *Client) error {
+		if l == nil {
+			l = noopLogger{}
+		}
+
+		c.logger = l
+		return nil
+	}
+}
+
+// debugRequest logs method, URL and body of the request. The Authorization
+// header is never logged and secrets in the body are redacted.
+func (c *Client) debugRequest(prefix string, req *http.Request) {
+	if _, ok := c.logger.(noopLogger); ok {
+		return
+	}
+
+	c.logger.Debugf("%s req: %s %s %s", prefix, req.Method, req.URL, redactRequestBody(req))
+}
+
+// redactRequestBody returns the JSON body of the request with secrets redacted,
+// the request body itself is left untouched.
+func redactRequestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+
+	return redactJSON(data)
+}
+
+// jsonRequestBody returns the redacted request body as JSON, non JSON bodies
+// are encoded as a string.
+func jsonRequestBody(req *http.Request) json.RawMessage {
+	body := redactRequestBody(req)
+	if len(body) == 0 {
+		return nil
+	}
+
+	if !json.Valid(body) {
+		body, _ = json.Marshal(string(body))
+	}
+
+	return body
+}
+
+// redactJSON replaces values of sensitive keys such as passwords and API keys
+// in a JSON document. Non JSON data is returned as is.
+func redactJSON(data []byte) []byte {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return data
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+
+	res, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return data
+	}
+
+	return res
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if sensitiveKey(k) {
+				val[k] = redacted
+			} else {
+				val[k] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
+
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, s := range []string{"password", "secret", "token", "api_key", "encryption_key", "licensing_key"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+
+	return false
+}
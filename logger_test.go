@@ -0,0 +1,55 @@
+package onappgo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugRequest_redacted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	require.NoError(t, SetLogger(NewStdLogger(log.New(&buf, "", 0), LogLevelDebug))(client))
+
+	mux.HandleFunc("/users.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"user":{"id":1}}`)
+	})
+
+	createRequest := &UserCreateRequest{
+		Login:    "test",
+		Password: "secret-password",
+	}
+	_, _, err := client.Users.Create(ctx, createRequest)
+	require.NoError(t, err)
+
+	out := buf.String()
+	require.Contains(t, out, "[DEBUG] User [Create] req: POST")
+	require.Contains(t, out, `"login":"test"`)
+	require.NotContains(t, out, "secret-password")
+	require.NotContains(t, out, "Basic")
+}
+
+func TestStdLogger_level(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), LogLevelInfo)
+
+	l.Debugf("debug")
+	l.Infof("info")
+	l.Errorf("error")
+
+	require.Equal(t, "[INFO] info\n[ERROR] error\n", buf.String())
+}
+
+func TestRedactJSON(t *testing.T) {
+	got := redactJSON([]byte(`{"user":{"password":"p","api_key":"k","roles":[{"initial_root_password":"r","label":"l"}]}}`))
+
+	require.False(t, strings.Contains(string(got), `"p"`) || strings.Contains(string(got), `"k"`) || strings.Contains(string(got), `"r"`))
+	require.Contains(t, string(got), `"label":"l"`)
+}
@@ -0,0 +1,82 @@
+package onappgo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoundTripperFunc sends a single API request and returns its response.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every API request made by Client.Do. It may
+// change the request, the response or replace the call altogether.
+type Middleware func(next RoundTripperFunc) RoundTripperFunc
+
+// TimingCallback receives the duration of a request sent through TimingMiddleware.
+type TimingCallback func(req *http.Request, resp *http.Response, err error, duration time.Duration)
+
+// Use appends middlewares to the client. The first added middleware is the
+// outermost one. Every retry attempt goes through the whole chain. Use must not
+// be called concurrently with requests.
+func (c *Client) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+}
+
+// SetMiddlewares is a client option for appending middlewares, see Client.Use.
+func SetMiddlewares(mw ...Middleware) ClientOpt {
+	return func(c *Client) error {
+		c.Use(mw...)
+		return nil
+	}
+}
+
+// roundTrip sends the request through the middleware chain to the HTTP client.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	hc := c.client
+	if c.recorder != nil {
+		recorded := *hc
+		recorded.Transport = &recorderTransport{recorder: c.recorder, next: hc.Transport}
+		hc = &recorded
+	}
+
+	return c.chain(hc.Do)(req)
+}
+
+// chain wraps send with the middlewares.
+func (c *Client) chain(send RoundTripperFunc) RoundTripperFunc {
+	next := send
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+
+	return next
+}
+
+// RequestIDMiddleware sets a random X-Request-Id header on requests which
+// have none, so that they can be found in the control panel logs.
+func RequestIDMiddleware() Middleware {
+	return func(next RoundTripperFunc) RoundTripperFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(headerRequestID) == "" {
+				req.Header.Set(headerRequestID, uuid.New().String())
+			}
+
+			return next(req)
+		}
+	}
+}
+
+// TimingMiddleware reports how long every request took to the callback.
+func TimingMiddleware(callback TimingCallback) Middleware {
+	return func(next RoundTripperFunc) RoundTripperFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			callback(req, resp, err, time.Since(start))
+
+			return resp, err
+		}
+	}
+}
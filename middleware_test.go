@@ -0,0 +1,62 @@
+package onappgo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Use(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next RoundTripperFunc) RoundTripperFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Add("X-Trace", name)
+				return next(req)
+			}
+		}
+	}
+
+	var timed time.Duration
+	client.Use(tag("outer"), tag("inner"), RequestIDMiddleware(), TimingMiddleware(
+		func(req *http.Request, resp *http.Response, err error, d time.Duration) {
+			timed = d
+		}))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, []string{"outer", "inner"}, r.Header.Values("X-Trace"))
+		require.NotEmpty(t, r.Header.Get(headerRequestID))
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.NotZero(t, timed)
+}
+
+func TestClient_Use_shortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not reach the server")
+	})
+
+	client.Use(func(next RoundTripperFunc) RoundTripperFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, ErrServer
+		}
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := client.Do(ctx, req, nil)
+	require.True(t, errors.Is(err, ErrServer))
+}
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const networksBasePath string = "settings/networks"
 // https://docs.onapp.com/apim/latest/networks
 type NetworksService interface {
 	List(context.Context, *ListOptions) ([]Network, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Network, *Response, error)
 	Get(context.Context, int) (*Network, *Response, error)
 	Create(context.Context, *NetworkCreateRequest) (*Network, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type NetworksServiceOp struct {
 
 var _ NetworksService = &NetworksServiceOp{}
 
+var networksCRUD = crud[Network]{name: "Network", path: networksBasePath, root: "network"}
+
 // Network represents a Network
 type Network struct {
 	ID                        int    `json:"id,omitempty"`
@@ -82,130 +82,36 @@ type NetworkEditRequest struct {
 	Vlan           int    `json:"vlan,omitempty"`
 }
 
-type networkCreateRequestRoot struct {
-	NetworkCreateRequest *NetworkCreateRequest `json:"network"`
-}
-
-type networkRoot struct {
-	Network *Network `json:"network"`
-}
-
 func (d NetworkCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Networks.
 func (s *NetworksServiceOp) List(ctx context.Context, opt *ListOptions) ([]Network, *Response, error) {
-	path := networksBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Network
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Network, len(out))
-	for i := range arr {
-		arr[i] = out[i]["network"]
-	}
-
-	return arr, resp, err
+	return networksCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of Networks.
+func (s *NetworksServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Network, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Network.
 func (s *NetworksServiceOp) Get(ctx context.Context, id int) (*Network, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networksBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(networkRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Network, resp, err
+	return networksCRUD.get(ctx, s.client, id)
 }
 
 // Create Network.
 func (s *NetworksServiceOp) Create(ctx context.Context, createRequest *NetworkCreateRequest) (*Network, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("Network createRequest", "cannot be nil")
-	}
-
-	path := networksBasePath + apiFormat
-	rootRequest := &networkCreateRequestRoot{
-		NetworkCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("Network [Create] req: ", req)
-
-	root := new(networkRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Network, resp, err
+	return networksCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete Network.
 func (s *NetworksServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networksBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	fmt.Println("Network [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return networksCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Network.
 func (s *NetworksServiceOp) Edit(ctx context.Context, id int, editRequest *NetworkEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("Network [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networksBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Network [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return networksCRUD.edit(ctx, s.client, id, editRequest)
 }
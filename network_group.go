@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const networkZonesBasePath string = "settings/network_zones"
 // https://docs.onapp.com/apim/latest/network-zones
 type NetworkGroupsService interface {
 	List(context.Context, *ListOptions) ([]NetworkGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]NetworkGroup, *Response, error)
 	Get(context.Context, int) (*NetworkGroup, *Response, error)
 	Create(context.Context, *NetworkGroupCreateRequest) (*NetworkGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type NetworkGroupsServiceOp struct {
 
 var _ NetworkGroupsService = &NetworkGroupsServiceOp{}
 
+var networkGroupsCRUD = crud[NetworkGroup]{name: "NetworkGroup", path: networkZonesBasePath, root: "network_group"}
+
 // NetworkGroup represents a NetworkGroup
 type NetworkGroup struct {
 	AdditionalFields  []AdditionalFields `json:"additional_fields,omitempty"`
@@ -66,130 +66,36 @@ type NetworkGroupEditRequest struct {
 	PreconfiguredOnly bool   `json:"preconfigured_only,bool"`
 }
 
-type networkZoneCreateRequestRoot struct {
-	NetworkGroupCreateRequest *NetworkGroupCreateRequest `json:"network_group"`
-}
-
-type networkZoneRoot struct {
-	NetworkGroup *NetworkGroup `json:"network_group"`
-}
-
 func (d NetworkGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all NetworkGroups.
 func (s *NetworkGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]NetworkGroup, *Response, error) {
-	path := networkZonesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]NetworkGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]NetworkGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["network_group"]
-	}
-
-	return arr, resp, err
+	return networkGroupsCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of NetworkGroups.
+func (s *NetworkGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]NetworkGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual NetworkGroup.
 func (s *NetworkGroupsServiceOp) Get(ctx context.Context, id int) (*NetworkGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networkZonesBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(networkZoneRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.NetworkGroup, resp, err
+	return networkGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create NetworkGroup.
 func (s *NetworkGroupsServiceOp) Create(ctx context.Context, createRequest *NetworkGroupCreateRequest) (*NetworkGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("NetworkGroup createRequest", "cannot be nil")
-	}
-
-	path := networkZonesBasePath + apiFormat
-	rootRequest := &networkZoneCreateRequestRoot{
-		NetworkGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("NetworkGroup [Create] req: ", req)
-
-	root := new(networkZoneRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.NetworkGroup, resp, err
+	return networkGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete NetworkGroup.
 func (s *NetworkGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networkZonesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("NetworkGroup [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return networkGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit NetworkGroup.
 func (s *NetworkGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *NetworkGroupEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("NetworkGroup [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", networkZonesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("NetworkGroup [Edit] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return networkGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -30,6 +27,8 @@ type NetworkInterfacesServiceOp struct {
 
 var _ NetworkInterfacesService = &NetworkInterfacesServiceOp{}
 
+var networkInterfacesCRUD = crud[NetworkInterface]{name: "NetworkInterface", path: networkInterfacesBasePath, root: "network_interface"}
+
 // NetworkInterface represents a NetworkInterface
 type NetworkInterface struct {
 	AdapterType         string `json:"adapter_type,omitempty"`
@@ -67,65 +66,22 @@ type NetworkInterfaceEditRequest struct {
 	RateLimit int    `json:"rate_limit,omitempty"`
 }
 
-type networkInterfaceCreateRequestRoot struct {
-	NetworkInterfaceCreateRequest *NetworkInterfaceCreateRequest `json:"network_interface"`
-}
-
-type networkInterfaceRoot struct {
-	NetworkInterface *NetworkInterface `json:"network_interface"`
-}
-
 func (d NetworkInterfaceCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all NetworkInterfaces.
 func (s *NetworkInterfacesServiceOp) List(ctx context.Context, vmID int, opt *ListOptions) ([]NetworkInterface, *Response, error) {
-	path := fmt.Sprintf(networkInterfacesBasePath, vmID) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]NetworkInterface
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]NetworkInterface, len(out))
-	for i := range arr {
-		arr[i] = out[i]["network_interface"]
-	}
-
-	return arr, resp, err
+	return networkInterfacesCRUD.at(vmID).list(ctx, s.client, opt)
 }
 
 // Get individual NetworkInterface.
 func (s *NetworkInterfacesServiceOp) Get(ctx context.Context, vmID int, id int) (*NetworkInterface, *Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(networkInterfacesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(networkInterfaceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
+	if vmID < 1 {
+		return nil, nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
 
-	return root.NetworkInterface, resp, err
+	return networkInterfacesCRUD.at(vmID).get(ctx, s.client, id)
 }
 
 // Create NetworkInterface.
@@ -134,70 +90,23 @@ func (s *NetworkInterfacesServiceOp) Create(ctx context.Context, vmID int, creat
 		return nil, nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
 
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("NetworkInterface createRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(networkInterfacesBasePath, vmID) + apiFormat
-	rootRequest := &networkInterfaceCreateRequestRoot{
-		NetworkInterfaceCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("NetworkInterface [Create] req: ", req)
-
-	root := new(networkInterfaceRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.NetworkInterface, resp, err
+	return networkInterfacesCRUD.at(vmID).create(ctx, s.client, createRequest)
 }
 
 // Delete NetworkInterface.
 func (s *NetworkInterfacesServiceOp) Delete(ctx context.Context, vmID int, id int, meta interface{}) (*Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(networkInterfacesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
+	if vmID < 1 {
+		return nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
-	fmt.Println("NetworkInterface [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return networkInterfacesCRUD.at(vmID).delete(ctx, s.client, id, meta)
 }
 
 // Edit NetworkInterface.
 func (s *NetworkInterfacesServiceOp) Edit(ctx context.Context, vmID int, id int, editRequest *NetworkInterfaceEditRequest) (*Response, error) {
-	if vmID < 1 || id < 1 {
-		return nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("NetworkInterface [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(networkInterfacesBasePath, vmID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
+	if vmID < 1 {
+		return nil, godo.NewArgError("vmID", "cannot be less than 1")
 	}
-	log.Println("NetworkInterface [Edit]  req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return networkInterfacesCRUD.at(vmID).edit(ctx, s.client, id, editRequest)
 }
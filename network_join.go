@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -29,6 +27,8 @@ type NetworkJoinsServiceOp struct {
 
 var _ NetworkJoinsService = &NetworkJoinsServiceOp{}
 
+var networkJoinsCRUD = crud[NetworkJoin]{name: "NetworkJoin", root: "networking_network_join"}
+
 // NetworkJoin represents a NetworkJoin
 type NetworkJoin struct {
 	ID             int    `json:"id,omitempty"`
@@ -56,139 +56,60 @@ type NetworkJoinDeleteRequest struct {
 	TargetJoinType string
 }
 
-type networkJoinCreateRequestRoot struct {
-	NetworkJoinCreateRequest *NetworkJoinCreateRequest `json:"network_join"`
-}
-
-type networkJoinRoot struct {
-	NetworkJoin *NetworkJoin `json:"networking_network_join"`
-}
-
 func (d NetworkJoinCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all NetworkJoins.
 func (s *NetworkJoinsServiceOp) List(ctx context.Context, createRequest *NetworkJoinCreateRequest, opt *ListOptions) ([]NetworkJoin, *Response, error) {
-	path := ""
-	if val, ok := networkJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("NetworkJoin List: wrong TargetJoinType", createRequest.TargetJoinType)
-	}
-
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
+	if createRequest == nil {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := networkJoinsCRUD.join(networkJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var out []map[string]NetworkJoin
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]NetworkJoin, len(out))
-	for i := range arr {
-		arr[i] = out[i]["networking_network_join"]
-	}
-
-	return arr, resp, err
+	return r.list(ctx, s.client, opt)
 }
 
 // Get individual NetworkJoin.
 func (s *NetworkJoinsServiceOp) Get(ctx context.Context, targetJoinType string, targetJoinID int, id int) (*NetworkJoin, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := networkJoinPaths[targetJoinType]; ok {
-		path = fmt.Sprintf(val, targetJoinID)
-	} else {
-		return nil, nil, godo.NewArgError("NetworkJoin Get: wrong TargetJoinType", targetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	r, err := networkJoinsCRUD.join(networkJoinPaths, targetJoinType, targetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	root := new(networkJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.NetworkJoin, resp, err
+	return r.get(ctx, s.client, id)
 }
 
 // Create NetworkJoin.
 func (s *NetworkJoinsServiceOp) Create(ctx context.Context, createRequest *NetworkJoinCreateRequest) (*NetworkJoin, *Response, error) {
 	if createRequest == nil {
-		return nil, nil, godo.NewArgError("NetworkJoin createRequest", "cannot be nil")
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := ""
-	if val, ok := networkJoinPaths[createRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, createRequest.TargetJoinID) + apiFormat
-	} else {
-		return nil, nil, godo.NewArgError("NetworkJoin Create: wrong TargetJoinType", createRequest.TargetJoinType)
-	}
-
-	rootRequest := &networkJoinCreateRequestRoot{
-		NetworkJoinCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
+	r, err := networkJoinsCRUD.join(networkJoinPaths, createRequest.TargetJoinType, createRequest.TargetJoinID)
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("NetworkJoin [Create] req: ", req)
 
-	root := new(networkJoinRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
+	rootRequest := wrapResource("network_join", createRequest)
 
-	return root.NetworkJoin, resp, err
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), rootRequest)
 }
 
 // Delete NetworkJoin.
 func (s *NetworkJoinsServiceOp) Delete(ctx context.Context, deleteRequest *NetworkJoinDeleteRequest, meta interface{}) (*Response, error) {
 	if deleteRequest == nil {
-		return nil, godo.NewArgError("NetworkJoin deleteRequest", "cannot be nil")
-	}
-
-	if deleteRequest.ID < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := ""
-	if val, ok := networkJoinPaths[deleteRequest.TargetJoinType]; ok {
-		path = fmt.Sprintf(val, deleteRequest.TargetJoinID)
-	} else {
-		return nil, godo.NewArgError("NetworkJoin Delete: wrong TargetJoinType", deleteRequest.TargetJoinType)
-	}
-
-	path = fmt.Sprintf("%s/%d%s", path, deleteRequest.ID, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
+		return nil, godo.NewArgError("deleteRequest", "cannot be nil")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	r, err := networkJoinsCRUD.join(networkJoinPaths, deleteRequest.TargetJoinType, deleteRequest.TargetJoinID)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("NetworkJoin [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.delete(ctx, s.client, deleteRequest.ID, meta)
 }
@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	sdk "github.com/OnApp/onapp-sdk-go/version"
 
@@ -45,8 +46,8 @@ type Client struct {
 	// User agent for client
 	UserAgent string
 
-	apiUser     string
-	apiPassword string
+	// Credentials used to authorize requests, none by default
+	credentials Credentials
 
 	// Services used for communicating with the API
 	Buckets                   BucketsService
@@ -56,6 +57,7 @@ type Client struct {
 	InstancePackages          InstancePackagesService
 	VirtualMachines           VirtualMachinesService
 	VirtualMachineActions     VirtualMachineActionsService
+	Console                   ConsoleService
 	Hypervisors               HypervisorsService
 	HypervisorGroups          HypervisorGroupsService
 	DataStores                DataStoresService
@@ -96,6 +98,38 @@ type Client struct {
 
 	// Optional function called after every successful request made to the OnApp APIs
 	onRequestCompleted RequestCompletionCallback
+
+	// Optional policy for retrying transient errors in Do
+	retryPolicy *RetryPolicy
+
+	// Logger used for all SDK output, discards everything by default
+	logger Logger
+
+	// Middlewares wrapping every request sent by Do
+	middlewares []Middleware
+
+	// Optional instrumentation of every API call
+	tracer  Tracer
+	metrics Metrics
+
+	// Optional limits of the request rate and concurrency
+	limiter         *limiter
+	serviceLimiters []serviceLimiter
+
+	// Optional cache of slow-changing catalogs
+	cache *responseCache
+
+	// Optional recorder wrapping the transport of the HTTP client
+	recorder *Recorder
+
+	// SHA-256 digests of the pinned public keys
+	pins [][]byte
+
+	// Plan of the dry-run mode
+	plan *Plan
+
+	// Optional receiver of the audit records
+	auditSink AuditSink
 }
 
 // RequestCompletionCallback defines the type of the request callback function
@@ -118,6 +152,17 @@ type Response struct {
 	// Links that were returned with the response. These are parsed from
 	// request body and not the header.
 	Links *Links
+
+	// Attempts is the number of times the request was sent, including retries.
+	// A request which ends in a transport error returns a Response with only
+	// Attempts set.
+	Attempts int
+
+	// RequestID returned in the X-Request-Id header
+	RequestID string
+
+	// audit record waiting for the transaction of the action
+	audit *AuditRecord
 }
 
 // An ErrorResponse reports the error caused by an API request
@@ -125,8 +170,11 @@ type ErrorResponse struct {
 	// HTTP response that caused this error
 	Response *http.Response
 
-	// Error messages
+	// Error messages by field, messages not related to a field are under "base"
 	Errors map[string][]string `json:"errors,omitempty"`
+
+	// RequestID returned in the X-Request-Id header
+	RequestID string
 }
 
 func addOptions(s string, opt interface{}) (string, error) {
@@ -164,7 +212,7 @@ func NewClient(httpClient *http.Client) *Client {
 
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent}
+	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, logger: noopLogger{}}
 
 	if t, ok := http.DefaultTransport.(*http.Transport); ok {
 		c.transport = &http.Transport{
@@ -186,6 +234,7 @@ func NewClient(httpClient *http.Client) *Client {
 	c.InstancePackages = &InstancePackagesServiceOp{client: c}
 	c.VirtualMachines = &VirtualMachinesServiceOp{client: c}
 	c.VirtualMachineActions = &VirtualMachineActionsServiceOp{client: c}
+	c.Console = &ConsoleServiceOp{client: c}
 	c.Hypervisors = &HypervisorsServiceOp{client: c}
 	c.HypervisorGroups = &HypervisorGroupsServiceOp{client: c}
 	c.DataStores = &DataStoresServiceOp{client: c}
@@ -228,6 +277,10 @@ func NewClient(httpClient *http.Client) *Client {
 }
 
 func (c *Client) certificate() *tls.Certificate {
+	if c.transport.TLSClientConfig == nil {
+		return nil
+	}
+
 	certs := c.transport.TLSClientConfig.Certificates
 	if len(certs) == 0 {
 		return nil
@@ -235,11 +288,12 @@ func (c *Client) certificate() *tls.Certificate {
 	return &certs[0]
 }
 
-func (c *Client) setCertificate(cert tls.Certificate) {
-	t := c.client.Transport.(*http.Transport)
-
-	// Extension or HoK certificate
-	t.TLSClientConfig.Certificates = []tls.Certificate{cert}
+func (c *Client) setCertificate(cert tls.Certificate) error {
+	return c.configureTLS(func(cfg *tls.Config) error {
+		// Extension or HoK certificate
+		cfg.Certificates = []tls.Certificate{cert}
+		return nil
+	})
 }
 
 // ClientOpt are options for New.
@@ -279,30 +333,28 @@ func SetUserAgent(ua string) ClientOpt {
 }
 
 // SetBasicAuth is a client option for setting the user and password for API call.
+// The password may be the API key of the user as well. Empty user and password
+// leave requests unauthenticated.
 func SetBasicAuth(user, password string) ClientOpt {
 	return func(c *Client) error {
-		c.apiUser = user
-		c.apiPassword = password
+		if user == "" && password == "" {
+			c.credentials = nil
+			return nil
+		}
+
+		c.credentials = &PasswordCredentials{Login: user, Password: password}
 		return nil
 	}
 }
 
 // SetAllowUnverifiedSSL is a client option for setting allowUnverifiedSSL.
+// Other TLS settings, e.g. from SetRootCAFiles, are kept.
 func SetAllowUnverifiedSSL(isv bool) ClientOpt {
 	return func(c *Client) error {
-		c.transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: isv}
-
-		// Don't bother setting DialTLS if InsecureSkipVerify=true
-		if !isv {
-			c.transport.DialTLS = nil
-		}
-
-		c.client.Transport = c.transport
-
-		if cert := c.certificate(); cert != nil {
-			c.setCertificate(*cert)
-		}
-		return nil
+		return c.configureTLS(func(cfg *tls.Config) error {
+			cfg.InsecureSkipVerify = isv
+			return nil
+		})
 	}
 }
 
@@ -330,14 +382,13 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 		return nil, err
 	}
 
-	// maybe resolve problem with EOF error during POST request
-	req.Close = true
-
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
 
-	req.SetBasicAuth(c.apiUser, c.apiPassword)
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
@@ -352,6 +403,7 @@ func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
 	if response != nil {
 		response.populateLinks()
+		response.RequestID = r.Header.Get(headerRequestID)
 	}
 
 	return response
@@ -371,28 +423,52 @@ func (r *Response) populateLinks() {
 	r.Links.PerPage, _ = strconv.Atoi(limit)
 	r.Links.CurPage, _ = strconv.Atoi(page)
 	r.Links.Total, _ = strconv.Atoi(total)
-	r.Links.NumPages = int(r.Links.Total/r.Links.PerPage) + 1
+
+	if r.Links.PerPage > 0 {
+		r.Links.NumPages = (r.Links.Total + r.Links.PerPage - 1) / r.Links.PerPage
+	}
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	started := time.Now()
+	ctx, finish := c.instrument(ctx, req)
+
+	response, err := c.do(ctx, req, v)
+	finish(response, err)
+	c.audit(ctx, started, req, response, err)
+
+	return response, err
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.planned(req) {
+		return nil, ErrDryRun
+	}
+
+	resp, attempts, err := c.send(ctx, req)
 	if err != nil {
+		if attempts > 0 {
+			return &Response{Attempts: attempts}, err
+		}
 		return nil, err
 	}
-	if c.onRequestCompleted != nil {
+	if c.onRequestCompleted != nil && attempts > 0 {
 		c.onRequestCompleted(req, resp)
 	}
 
 	defer func() {
+		// drain the body, so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
 		if rerr := resp.Body.Close(); err == nil {
 			err = rerr
 		}
 	}()
 
 	response := newResponse(resp)
+	response.Attempts = attempts
 
 	err = CheckResponse(resp)
 	if err != nil {
@@ -428,22 +504,33 @@ func DoRequestWithClient(ctx context.Context, client *http.Client, req *http.Req
 }
 
 func (r *ErrorResponse) Error() string {
+	if r.RequestID != "" {
+		return fmt.Sprintf("%v %v: %d (request %s) %s",
+			r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.RequestID, r.String())
+	}
+
 	return fmt.Sprintf("%v %v: %d %s",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.String())
 }
 
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// body, or a JSON response body with an "errors" object or list, an "error" string or a bare list of messages.
+// Any other response body will be silently ignored. The returned *ErrorResponse matches the sentinel errors
+// such as ErrNotFound or ErrValidation with errors.Is.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{
+		Response:  r,
+		RequestID: r.Header.Get(headerRequestID),
+	}
+
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
-		json.Unmarshal(data, errorResponse)
+		errorResponse.Errors = parseErrors(data)
 	}
 
 	return errorResponse
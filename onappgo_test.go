@@ -2,6 +2,7 @@ package onappgo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -106,7 +107,7 @@ func testClientServices(t *testing.T, c *Client) {
 		"Configurations",
 		"UserGroups",
 		"FirewallRules",
-		"UserWhiteList",
+		"UserWhiteLists",
 	}
 
 	cp := reflect.ValueOf(c)
@@ -327,6 +328,90 @@ func TestErrorResponse_Error(t *testing.T) {
 	}
 }
 
+func TestCheckResponse_errorShapes(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected map[string][]string
+	}{
+		{
+			name:     "errors by field",
+			body:     `{"errors":{"label":["can't be blank"],"memory":["is too small","is not a number"]}}`,
+			expected: map[string][]string{"label": {"can't be blank"}, "memory": {"is too small", "is not a number"}},
+		},
+		{
+			name:     "list of errors",
+			body:     `{"errors":["VS is locked"]}`,
+			expected: map[string][]string{"base": {"VS is locked"}},
+		},
+		{
+			name:     "single error",
+			body:     `{"error":"Resource not found"}`,
+			expected: map[string][]string{"base": {"Resource not found"}},
+		},
+		{
+			name:     "bare list",
+			body:     `["first", "second"]`,
+			expected: map[string][]string{"base": {"first", "second"}},
+		},
+		{
+			name:     "not a JSON",
+			body:     `<html>Bad Gateway</html>`,
+			expected: nil,
+		},
+	}
+
+	for _, c := range cases {
+		res := &http.Response{
+			Request:    &http.Request{},
+			StatusCode: http.StatusUnprocessableEntity,
+			Header:     http.Header{headerRequestID: []string{"req-1"}},
+			Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+		}
+		err := CheckResponse(res).(*ErrorResponse)
+
+		if !reflect.DeepEqual(err.Errors, c.expected) {
+			t.Errorf("%q Errors = %#v, expected %#v", c.name, err.Errors, c.expected)
+		}
+
+		if err.RequestID != "req-1" {
+			t.Errorf("%q RequestID = %q, expected %q", c.name, err.RequestID, "req-1")
+		}
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	cases := []struct {
+		code     int
+		expected error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusLocked, ErrConflict},
+		{http.StatusServiceUnavailable, ErrServer},
+	}
+
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrValidation, ErrConflict, ErrServer}
+
+	for _, c := range cases {
+		var err error = &ErrorResponse{Response: &http.Response{Request: &http.Request{}, StatusCode: c.code}}
+		err = fmt.Errorf("wrapped: %w", err)
+
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == c.expected) {
+				t.Errorf("errors.Is(%d, %v) = %v", c.code, sentinel, got)
+			}
+		}
+
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) {
+			t.Errorf("errors.As(%d) failed", c.code)
+		}
+	}
+}
+
 func checkCurrentPage(t *testing.T, resp *Response, expectedPage int) {
 	links := resp.Links
 	p, err := links.CurrentPage()
@@ -0,0 +1,392 @@
+package onappgotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	onappgo "github.com/OnApp/onapp-sdk-go"
+)
+
+// virtualMachineAction describes an action endpoint of a virtual machine
+type virtualMachineAction struct {
+	// action of the created transaction
+	action string
+
+	// effect on the virtual machine once the transaction completes
+	effect func(vm object, params object)
+}
+
+// virtualMachineActions by the last path segment, see VirtualMachineActionsService
+var virtualMachineActions = map[string]virtualMachineAction{
+	"startup": {"startup_virtual_machine", func(vm object, _ object) {
+		vm["booted"] = true
+	}},
+	"shutdown": {"stop_virtual_machine", func(vm object, _ object) {
+		vm["booted"] = false
+	}},
+	"stop": {"stop_virtual_machine", func(vm object, _ object) {
+		vm["booted"] = false
+	}},
+	"reboot": {"reboot_virtual_machine", func(vm object, _ object) {
+		vm["booted"] = true
+	}},
+	"unlock": {"startup_virtual_machine", func(vm object, _ object) {
+		vm["locked"] = false
+	}},
+	"suspend": {"stop_virtual_machine", func(vm object, _ object) {
+		suspended := !boolValue(vm["suspended"])
+		vm["suspended"] = suspended
+		if suspended {
+			vm["booted"] = false
+		}
+	}},
+	"reset_password": {"reset_root_password", func(vm object, params object) {
+		if password, ok := params["initial_root_password"]; ok {
+			vm["initial_root_password"] = password
+		}
+	}},
+	"fqdn": {"update_fqdn", func(vm object, params object) {
+		for _, k := range []string{"hostname", "domain"} {
+			if v, ok := params[k]; ok && v != "" {
+				vm[k] = v
+			}
+		}
+	}},
+	"rebuild_network": {"rebuild_network", nil},
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.autoAdvance > 0 {
+		s.Clock.Advance(s.autoAdvance)
+	}
+	s.settle()
+
+	w.Header().Set("Date", s.Clock.Now().UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Type", "application/json")
+
+	if s.user != "" || s.password != "" {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != s.user || password != s.password {
+			writeError(w, http.StatusUnauthorized, "You are not authorized to perform this action")
+			return
+		}
+	}
+
+	path := strings.TrimSuffix(strings.Trim(r.URL.Path, "/"), ".json")
+	segments := strings.Split(path, "/")
+
+	if segments[0] == transactionsPath {
+		s.serveTransactions(w, r, segments[1:])
+		return
+	}
+
+	for _, c := range s.collections {
+		prefix := strings.Split(c.path, "/")
+		if len(segments) < len(prefix) || strings.Join(segments[:len(prefix)], "/") != c.path {
+			continue
+		}
+
+		s.serveCollection(w, r, c, segments[len(prefix):])
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "Page not found")
+}
+
+func (s *Server) serveTransactions(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	now := s.Clock.Now()
+
+	if len(rest) == 0 {
+		var lst []object
+		for i := len(s.transactions) - 1; i >= 0; i-- {
+			lst = append(lst, wrap("transaction", s.transactions[i].snapshot(now, s.pending, s.running)))
+		}
+		s.writePage(w, r, lst)
+		return
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || id < 1 || id > len(s.transactions) || len(rest) > 1 {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wrap("transaction", s.transactions[id-1].snapshot(now, s.pending, s.running)))
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, c *collection, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.writeObjects(w, r, c.root, c.list(nil))
+		case http.MethodPost:
+			s.create(w, r, c, nil)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	obj, ok := c.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, object{c.root: obj})
+		case http.MethodPut, http.MethodPatch:
+			params, err := readParams(r, c.root)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			c.update(id, params, s.Clock.Now())
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			s.delete(w, c, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	if len(rest) != 2 {
+		writeError(w, http.StatusNotFound, "Page not found")
+		return
+	}
+
+	switch c.path {
+	case virtualMachinesPath:
+		s.serveVirtualMachine(w, r, c, id, rest[1])
+	case usersPath:
+		if rest[1] == "make_new_api_key" && r.Method == http.MethodPost {
+			res := object{}
+			for k, v := range obj {
+				res[k] = v
+			}
+			res["api_key"] = fmt.Sprintf("%040d", s.Clock.Now().UnixNano())
+
+			writeJSON(w, http.StatusOK, object{c.root: res})
+			return
+		}
+		writeError(w, http.StatusNotFound, "Page not found")
+	default:
+		writeError(w, http.StatusNotFound, "Page not found")
+	}
+}
+
+func (s *Server) serveVirtualMachine(w http.ResponseWriter, r *http.Request, c *collection, id int, sub string) {
+	disks := s.collection(disksPath)
+
+	switch {
+	case sub == "disks" && r.Method == http.MethodGet:
+		s.writeObjects(w, r, disks.root, disks.list(func(d object) bool {
+			return intValue(d["virtual_machine_id"]) == id
+		}))
+		return
+	case sub == "disks" && r.Method == http.MethodPost:
+		s.create(w, r, disks, object{"virtual_machine_id": id})
+		return
+	case sub == "transactions" && r.Method == http.MethodGet:
+		now := s.Clock.Now()
+
+		var lst []object
+		for i := len(s.transactions) - 1; i >= 0; i-- {
+			t := s.transactions[i]
+			if t.AssociatedObjectType == c.objectType && t.AssociatedObjectID == id {
+				lst = append(lst, wrap("transaction", t.snapshot(now, s.pending, s.running)))
+			}
+		}
+		s.writePage(w, r, lst)
+		return
+	}
+
+	action, ok := virtualMachineActions[sub]
+	if !ok || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+		writeError(w, http.StatusNotFound, "Page not found")
+		return
+	}
+
+	params, err := readParams(r, c.root)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.newTransaction(action.action, c.objectType, id, false, func() {
+		if vm, ok := c.items[id]; ok && action.effect != nil {
+			action.effect(vm, params)
+		}
+	})
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// create stores a new object, virtual machines and disks are built by a transaction.
+func (s *Server) create(w http.ResponseWriter, r *http.Request, c *collection, defaults object) {
+	params, err := readParams(r, c.root)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for k, v := range defaults {
+		params[k] = v
+	}
+
+	obj := c.create(params, s.Clock.Now())
+	id := intValue(obj["id"])
+
+	switch c.path {
+	case virtualMachinesPath:
+		obj["built"] = false
+		obj["booted"] = false
+		startup := boolValue(params["required_virtual_machine_startup"])
+
+		s.newTransaction("build_virtual_machine", c.objectType, id, false, func() {
+			if vm, ok := c.items[id]; ok {
+				vm["built"] = true
+				vm["booted"] = startup
+			}
+		})
+	case disksPath:
+		obj["built"] = false
+
+		s.newTransaction("build_disk", c.objectType, id, true, func() {
+			if disk, ok := c.items[id]; ok {
+				disk["built"] = true
+			}
+		})
+	}
+
+	writeJSON(w, http.StatusCreated, object{c.root: obj})
+}
+
+// delete removes the object, virtual machines and disks once a transaction completes.
+func (s *Server) delete(w http.ResponseWriter, c *collection, id int) {
+	switch c.path {
+	case virtualMachinesPath:
+		disks := s.collection(disksPath)
+
+		s.newTransaction("destroy_virtual_machine", c.objectType, id, false, func() {
+			delete(c.items, id)
+			for diskID, disk := range disks.items {
+				if intValue(disk["virtual_machine_id"]) == id {
+					delete(disks.items, diskID)
+				}
+			}
+		})
+	case disksPath:
+		s.newTransaction("destroy_disk", c.objectType, id, true, func() {
+			delete(c.items, id)
+		})
+	default:
+		delete(c.items, id)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) writeObjects(w http.ResponseWriter, r *http.Request, root string, objects []object) {
+	lst := make([]object, len(objects))
+	for i, obj := range objects {
+		lst[i] = object{root: obj}
+	}
+
+	s.writePage(w, r, lst)
+}
+
+// writePage writes a page of the list with the paging headers.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, lst []object) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = s.perPage
+	}
+
+	w.Header().Set("X-Page", strconv.Itoa(page))
+	w.Header().Set("X-Limit", strconv.Itoa(perPage))
+	w.Header().Set("X-Total", strconv.Itoa(len(lst)))
+
+	from := (page - 1) * perPage
+	if from > len(lst) {
+		from = len(lst)
+	}
+
+	to := from + perPage
+	if to > len(lst) {
+		to = len(lst)
+	}
+
+	res := lst[from:to]
+	if res == nil {
+		res = []object{}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// readParams decodes the request body, unwrapping the root key if present.
+func readParams(r *http.Request, root string) (object, error) {
+	params := object{}
+
+	if r.Body == nil {
+		return params, nil
+	}
+
+	var body object
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if err == io.EOF {
+			return params, nil
+		}
+		return nil, err
+	}
+
+	if inner, ok := body[root].(map[string]interface{}); ok {
+		return object(inner), nil
+	}
+
+	return body, nil
+}
+
+func wrap(root string, v onappgo.Transaction) object {
+	var obj object
+	_ = convert(v, nil, &obj)
+
+	return object{root: obj}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{"errors": []string{message}})
+}
@@ -0,0 +1,317 @@
+// Package onappgotest provides a stateful in-memory fake of the OnApp API for
+// tests of code built on top of onappgo.
+//
+// The fake serves the same JSON shapes as the control panel: objects wrapped
+// in their root key, lists of root wrapped objects and the X-Page, X-Limit
+// and X-Total paging headers. Actions create transactions which move from
+// pending to running to complete as the FakeClock of the server advances.
+//
+//	srv := onappgotest.NewServer()
+//	defer srv.Close()
+//
+//	client, _ := srv.Client()
+//	vm := srv.AddVirtualMachine(onappgo.VirtualMachine{Label: "test"})
+//
+//	trx, _, _ := client.VirtualMachineActions.Startup(ctx, vm.ID)
+//	srv.FinishTransactions()
+//	client.Transactions.Wait(ctx, trx.ID, nil)
+package onappgotest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	onappgo "github.com/OnApp/onapp-sdk-go"
+)
+
+const (
+	virtualMachinesPath = "virtual_machines"
+	disksPath           = "settings/disks"
+	networksPath        = "settings/networks"
+	usersPath           = "users"
+	bucketsPath         = "billing/buckets"
+	transactionsPath    = "transactions"
+
+	defaultPerPage         = 100
+	defaultPendingDuration = time.Second
+	defaultRunningDuration = 5 * time.Second
+)
+
+// Server is a fake OnApp control panel listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	// Clock drives the transactions of the server
+	Clock *FakeClock
+
+	mu           sync.Mutex
+	collections  []*collection
+	transactions []*transaction
+	failNext     map[string]int
+
+	pending     time.Duration
+	running     time.Duration
+	autoAdvance time.Duration
+	perPage     int
+
+	user     string
+	password string
+}
+
+// Option configures the Server.
+type Option func(*Server)
+
+// WithClock makes the server use the given clock.
+func WithClock(clock *FakeClock) Option {
+	return func(s *Server) {
+		s.Clock = clock
+	}
+}
+
+// WithTransactionDurations sets how long transactions stay pending and running.
+func WithTransactionDurations(pending, running time.Duration) Option {
+	return func(s *Server) {
+		s.pending = pending
+		s.running = running
+	}
+}
+
+// WithAutoAdvance advances the clock by d on every request, so that polling
+// clients see transactions finishing without the test driving the clock.
+func WithAutoAdvance(d time.Duration) Option {
+	return func(s *Server) {
+		s.autoAdvance = d
+	}
+}
+
+// WithBasicAuth makes the server reject requests without the given credentials.
+func WithBasicAuth(user, password string) Option {
+	return func(s *Server) {
+		s.user = user
+		s.password = password
+	}
+}
+
+// WithPerPage sets the default page size of lists.
+func WithPerPage(perPage int) Option {
+	return func(s *Server) {
+		s.perPage = perPage
+	}
+}
+
+// NewServer starts a fake OnApp server, the caller should Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		Clock:    NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		failNext: make(map[string]int),
+		pending:  defaultPendingDuration,
+		running:  defaultRunningDuration,
+		perPage:  defaultPerPage,
+	}
+
+	s.collections = []*collection{
+		newCollection(virtualMachinesPath, "virtual_machine", "VirtualMachine", sanitizer[onappgo.VirtualMachine]()),
+		newCollection(disksPath, "disk", "Disk", sanitizer[onappgo.Disk]()),
+		newCollection(networksPath, "network", "Network", sanitizer[onappgo.Network]()),
+		newCollection(usersPath, "user", "User", sanitizer[onappgo.User]()),
+		newCollection(bucketsPath, "bucket", "Bucket", sanitizer[onappgo.Bucket]()),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an onappgo.Client talking to the server.
+func (s *Server) Client(opts ...onappgo.ClientOpt) (*onappgo.Client, error) {
+	opts = append([]onappgo.ClientOpt{
+		onappgo.SetBaseURL(s.URL),
+		onappgo.SetBasicAuth(s.user, s.password),
+	}, opts...)
+
+	return onappgo.New(s.Server.Client(), opts...)
+}
+
+// FinishTransactions advances the clock until all transactions are finished.
+func (s *Server) FinishTransactions() {
+	s.Clock.Advance(s.pending + s.running)
+}
+
+// FailNext makes the next transaction with the given action fail.
+func (s *Server) FailNext(action string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNext[action]++
+}
+
+// Transactions returns all transactions as seen at the current clock time.
+func (s *Server) Transactions() []onappgo.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settle()
+
+	now := s.Clock.Now()
+	res := make([]onappgo.Transaction, len(s.transactions))
+	for i, t := range s.transactions {
+		res[i] = t.snapshot(now, s.pending, s.running)
+	}
+
+	return res
+}
+
+// AddVirtualMachine stores the virtual machine and returns it with ID set.
+func (s *Server) AddVirtualMachine(vm onappgo.VirtualMachine) onappgo.VirtualMachine {
+	return seed(s, virtualMachinesPath, vm)
+}
+
+// VirtualMachine returns the stored virtual machine.
+func (s *Server) VirtualMachine(id int) (onappgo.VirtualMachine, bool) {
+	return lookup[onappgo.VirtualMachine](s, virtualMachinesPath, id)
+}
+
+// AddDisk stores the disk and returns it with ID set.
+func (s *Server) AddDisk(disk onappgo.Disk) onappgo.Disk {
+	return seed(s, disksPath, disk)
+}
+
+// Disk returns the stored disk.
+func (s *Server) Disk(id int) (onappgo.Disk, bool) {
+	return lookup[onappgo.Disk](s, disksPath, id)
+}
+
+// AddNetwork stores the network and returns it with ID set.
+func (s *Server) AddNetwork(network onappgo.Network) onappgo.Network {
+	return seed(s, networksPath, network)
+}
+
+// Network returns the stored network.
+func (s *Server) Network(id int) (onappgo.Network, bool) {
+	return lookup[onappgo.Network](s, networksPath, id)
+}
+
+// AddUser stores the user and returns it with ID set.
+func (s *Server) AddUser(user onappgo.User) onappgo.User {
+	return seed(s, usersPath, user)
+}
+
+// User returns the stored user.
+func (s *Server) User(id int) (onappgo.User, bool) {
+	return lookup[onappgo.User](s, usersPath, id)
+}
+
+// AddBucket stores the bucket and returns it with ID set.
+func (s *Server) AddBucket(bucket onappgo.Bucket) onappgo.Bucket {
+	return seed(s, bucketsPath, bucket)
+}
+
+// Bucket returns the stored bucket.
+func (s *Server) Bucket(id int) (onappgo.Bucket, bool) {
+	return lookup[onappgo.Bucket](s, bucketsPath, id)
+}
+
+func seed[T any](s *Server, path string, v T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var obj object
+	_ = convert(v, nil, &obj)
+
+	var res T
+	_ = convert(s.collection(path).create(obj, s.Clock.Now()), nil, &res)
+
+	return res
+}
+
+func lookup[T any](s *Server, path string, id int) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settle()
+
+	var res T
+	obj, ok := s.collection(path).items[id]
+	if !ok {
+		return res, false
+	}
+
+	_ = convert(obj, nil, &res)
+	return res, true
+}
+
+func (s *Server) collection(path string) *collection {
+	for _, c := range s.collections {
+		if c.path == path {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// newTransaction schedules a transaction, effect is applied once it completes.
+func (s *Server) newTransaction(action, objectType string, objectID int, parent bool, effect func()) *onappgo.Transaction {
+	now := s.Clock.Now()
+	id := len(s.transactions) + 1
+
+	t := &transaction{
+		Transaction: onappgo.Transaction{
+			ID:            id,
+			ChainID:       id,
+			Action:        action,
+			Actor:         s.user,
+			AllowedCancel: true,
+			CreatedAt:     now.Format(timeFormat),
+			UpdatedAt:     now.Format(timeFormat),
+			Identifier:    "fake",
+			Priority:      10,
+		},
+		created: now,
+		effect:  effect,
+	}
+
+	if parent {
+		t.ParentID = objectID
+		t.ParentType = objectType
+	} else {
+		t.AssociatedObjectID = objectID
+		t.AssociatedObjectType = objectType
+	}
+
+	if s.failNext[action] > 0 {
+		s.failNext[action]--
+		t.fail = true
+	}
+
+	s.transactions = append(s.transactions, t)
+
+	res := t.snapshot(now, s.pending, s.running)
+	return &res
+}
+
+// settle applies the effects of the transactions completed by now.
+func (s *Server) settle() {
+	now := s.Clock.Now()
+
+	for _, t := range s.transactions {
+		if t.settled {
+			continue
+		}
+
+		switch t.status(now, s.pending, s.running) {
+		case onappgo.TransactionComplete:
+			t.settled = true
+			if t.effect != nil {
+				t.effect()
+			}
+		case onappgo.TransactionFailed:
+			t.settled = true
+		}
+	}
+}
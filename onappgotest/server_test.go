@@ -0,0 +1,165 @@
+package onappgotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	onappgo "github.com/OnApp/onapp-sdk-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_VirtualMachineLifecycle(t *testing.T) {
+	srv := NewServer(WithBasicAuth("admin", "secret"))
+	defer srv.Close()
+
+	client, err := srv.Client()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	vm, _, err := client.VirtualMachines.Create(ctx, &onappgo.VirtualMachineCreateRequest{
+		Label:                         "web",
+		RequiredVirtualMachineStartup: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "web", vm.Label)
+	require.False(t, vm.Built)
+
+	srv.FinishTransactions()
+
+	vm, _, err = client.VirtualMachines.Get(ctx, vm.ID)
+	require.NoError(t, err)
+	require.True(t, vm.Built)
+	require.True(t, vm.Booted)
+
+	trx, _, err := client.VirtualMachineActions.Stop(ctx, vm.ID)
+	require.NoError(t, err)
+	require.Equal(t, "stop_virtual_machine", trx.Action)
+	require.Equal(t, onappgo.TransactionPending, trx.Status)
+
+	srv.FinishTransactions()
+
+	trx, _, err = client.Transactions.Wait(ctx, trx.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, onappgo.TransactionComplete, trx.Status)
+
+	stored, ok := srv.VirtualMachine(vm.ID)
+	require.True(t, ok)
+	require.False(t, stored.Booted)
+
+	_, _, err = client.VirtualMachines.Delete(ctx, vm.ID, nil)
+	require.NoError(t, err)
+
+	srv.FinishTransactions()
+
+	_, _, err = client.VirtualMachines.Get(ctx, vm.ID)
+	require.True(t, errors.Is(err, onappgo.ErrNotFound))
+}
+
+func TestServer_FailNext(t *testing.T) {
+	srv := NewServer(WithAutoAdvance(defaultPendingDuration))
+	defer srv.Close()
+
+	client, err := srv.Client()
+	require.NoError(t, err)
+
+	vm := srv.AddVirtualMachine(onappgo.VirtualMachine{Label: "db", Built: true})
+	srv.FailNext("startup_virtual_machine")
+
+	ctx := context.Background()
+
+	trx, _, err := client.VirtualMachineActions.Startup(ctx, vm.ID)
+	require.NoError(t, err)
+
+	_, _, err = client.Transactions.Wait(ctx, trx.ID, &onappgo.TransactionWaitOptions{Interval: 1})
+
+	var trxErr *onappgo.TransactionError
+	require.True(t, errors.As(err, &trxErr))
+	require.Equal(t, onappgo.TransactionFailed, trxErr.Transaction.Status)
+
+	stored, _ := srv.VirtualMachine(vm.ID)
+	require.False(t, stored.Booted)
+}
+
+func TestServer_Paging(t *testing.T) {
+	srv := NewServer(WithPerPage(2))
+	defer srv.Close()
+
+	client, err := srv.Client()
+	require.NoError(t, err)
+
+	for _, label := range []string{"a", "b", "c", "d", "e"} {
+		srv.AddNetwork(onappgo.Network{Label: label})
+	}
+
+	ctx := context.Background()
+
+	networks, resp, err := client.Networks.List(ctx, &onappgo.ListOptions{Page: 2})
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	require.Equal(t, "c", networks[0].Label)
+	require.Equal(t, 3, resp.Links.NumPages)
+
+	networks, _, err = client.Networks.ListAll(ctx, &onappgo.ListAllOptions{PerPage: 2})
+	require.NoError(t, err)
+	require.Len(t, networks, 5)
+}
+
+func TestServer_Disks(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client, err := srv.Client()
+	require.NoError(t, err)
+
+	vm := srv.AddVirtualMachine(onappgo.VirtualMachine{Label: "app"})
+	srv.AddDisk(onappgo.Disk{Label: "other", VirtualMachineID: vm.ID + 1})
+
+	ctx := context.Background()
+
+	disk, _, err := client.Disks.Create(ctx, &onappgo.DiskCreateRequest{
+		VirtualMachineID: vm.ID,
+		Label:            "data",
+		DiskSize:         10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, vm.ID, disk.VirtualMachineID)
+
+	disks, _, err := client.Disks.List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, disks, 2)
+	require.Equal(t, "data", disks[1].Label)
+	require.False(t, disks[1].Built)
+
+	srv.FinishTransactions()
+
+	stored, ok := srv.Disk(disk.ID)
+	require.True(t, ok)
+	require.True(t, stored.Built)
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	srv := NewServer(WithBasicAuth("admin", "secret"))
+	defer srv.Close()
+
+	client, err := srv.Client(onappgo.SetBasicAuth("admin", "wrong"))
+	require.NoError(t, err)
+
+	_, _, err = client.Users.List(context.Background(), nil)
+	require.True(t, errors.Is(err, onappgo.ErrUnauthorized))
+}
+
+func TestServer_MakeNewAPIKey(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client, err := srv.Client()
+	require.NoError(t, err)
+
+	user := srv.AddUser(onappgo.User{Login: "jdoe", Email: "jdoe@example.com"})
+
+	key, _, err := client.Users.MakeNewAPIKey(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+}
@@ -0,0 +1,188 @@
+package onappgotest
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	onappgo "github.com/OnApp/onapp-sdk-go"
+)
+
+// timeFormat is the format of the timestamps returned by the OnApp API
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+type object map[string]interface{}
+
+// collection keeps the objects of a single resource as JSON objects, so that
+// they are served in the same shape as the real API.
+type collection struct {
+	path       string
+	root       string
+	objectType string
+	sanitize   func(object) object
+	next       int
+	items      map[int]object
+}
+
+func newCollection(path, root, objectType string, sanitize func(object) object) *collection {
+	return &collection{
+		path:       path,
+		root:       root,
+		objectType: objectType,
+		sanitize:   sanitize,
+		items:      make(map[int]object),
+	}
+}
+
+// sanitizer drops fields which are unknown to T or have a different type,
+// e.g. create request fields which are not part of the resource.
+func sanitizer[T any]() func(object) object {
+	return func(obj object) object {
+		var res object
+		if err := convert(obj, new(T), &res); err != nil {
+			return obj
+		}
+
+		return res
+	}
+}
+
+// convert moves data between types through JSON. Type mismatches of single
+// fields are skipped the same way as encoding/json does.
+func convert(from interface{}, through interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	if through != nil {
+		if err := json.Unmarshal(data, through); err != nil {
+			if _, ok := err.(*json.UnmarshalTypeError); !ok {
+				return err
+			}
+		}
+
+		data, err = json.Marshal(through)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = json.Unmarshal(data, to)
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		return nil
+	}
+
+	return err
+}
+
+func (c *collection) create(obj object, now time.Time) object {
+	c.next++
+
+	obj = c.sanitize(obj)
+	obj["id"] = c.next
+	obj["created_at"] = now.Format(timeFormat)
+	obj["updated_at"] = now.Format(timeFormat)
+
+	c.items[c.next] = obj
+	return obj
+}
+
+func (c *collection) update(id int, fields object, now time.Time) (object, bool) {
+	obj, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+
+	for k, v := range fields {
+		if k == "id" || k == "created_at" {
+			continue
+		}
+		obj[k] = v
+	}
+
+	obj = c.sanitize(obj)
+	obj["updated_at"] = now.Format(timeFormat)
+
+	c.items[id] = obj
+	return obj, true
+}
+
+func (c *collection) list(match func(object) bool) []object {
+	ids := make([]int, 0, len(c.items))
+	for id, obj := range c.items {
+		if match == nil || match(obj) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	res := make([]object, len(ids))
+	for i, id := range ids {
+		res[i] = c.items[id]
+	}
+
+	return res
+}
+
+// transaction is a transaction whose status follows the clock
+type transaction struct {
+	onappgo.Transaction
+
+	created time.Time
+	fail    bool
+	settled bool
+	effect  func()
+}
+
+// status returns the transaction status at the given time
+func (t *transaction) status(now time.Time, pending, running time.Duration) string {
+	elapsed := now.Sub(t.created)
+
+	switch {
+	case elapsed < pending:
+		return onappgo.TransactionPending
+	case elapsed < pending+running:
+		return onappgo.TransactionRunning
+	case t.fail:
+		return onappgo.TransactionFailed
+	}
+
+	return onappgo.TransactionComplete
+}
+
+// snapshot returns the transaction as seen at the given time
+func (t *transaction) snapshot(now time.Time, pending, running time.Duration) onappgo.Transaction {
+	res := t.Transaction
+	res.Status = t.status(now, pending, running)
+
+	if res.Status != onappgo.TransactionPending {
+		res.StartedAt = t.created.Add(pending).Format(timeFormat)
+	}
+
+	return res
+}
+
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+
+	return 0
+}
+
+func boolValue(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case float64:
+		return b != 0
+	case string:
+		return b == "1" || b == "true"
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package onappgo
+
+import (
+	"context"
+)
+
+const defaultListAllPerPage = 100
+
+// ListAllOptions specifies the optional parameters to the ListAll methods.
+type ListAllOptions struct {
+	// PerPage is the number of results requested per page, 100 by default.
+	PerPage int
+
+	// MaxItems stops paging once that many results are collected, 0 means no limit.
+	MaxItems int
+}
+
+// ListPageFunc fetches a single page of a List method.
+type ListPageFunc[T any] func(context.Context, *ListOptions) ([]T, *Response, error)
+
+// ListAllPages walks every page returned by list until Links reports the last
+// page, and returns the collected results with the last page Response.
+//
+// It can page any List method, also ones which need extra arguments:
+//
+//	nets, _, err := onappgo.ListAllPages(ctx, nil, func(ctx context.Context, opt *onappgo.ListOptions) ([]onappgo.IPNet, *onappgo.Response, error) {
+//		return client.IPNets.List(ctx, networkID, opt)
+//	})
+func ListAllPages[T any](ctx context.Context, opts *ListAllOptions, list ListPageFunc[T]) ([]T, *Response, error) {
+	if opts == nil {
+		opts = &ListAllOptions{}
+	}
+
+	opt := &ListOptions{
+		Page:    1,
+		PerPage: opts.PerPage,
+	}
+	if opt.PerPage <= 0 {
+		opt.PerPage = defaultListAllPerPage
+	}
+
+	var all []T
+	var resp *Response
+	for {
+		if err := ctx.Err(); err != nil {
+			return all, resp, err
+		}
+
+		page, pageResp, err := list(ctx, opt)
+		if err != nil {
+			return all, pageResp, err
+		}
+		resp = pageResp
+
+		all = append(all, page...)
+
+		if opts.MaxItems > 0 && len(all) >= opts.MaxItems {
+			return all[:opts.MaxItems], resp, nil
+		}
+
+		if len(page) == 0 || resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, resp, nil
+		}
+
+		opt.Page++
+	}
+}
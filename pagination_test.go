@@ -0,0 +1,72 @@
+package onappgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func handleVirtualMachinePages(t *testing.T, total int) {
+	mux.HandleFunc("/virtual_machines.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		w.Header().Set(headerPage, strconv.Itoa(page))
+		w.Header().Set(headerPerPage, strconv.Itoa(perPage))
+		w.Header().Set(headerTotal, strconv.Itoa(total))
+
+		fmt.Fprint(w, "[")
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= total; id++ {
+			if id > (page-1)*perPage+1 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"virtual_machine":{"id":%d}}`, id)
+		}
+		fmt.Fprint(w, "]")
+	})
+}
+
+func TestVirtualMachines_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleVirtualMachinePages(t, 5)
+
+	got, resp, err := client.VirtualMachines.ListAll(ctx, &ListAllOptions{PerPage: 2})
+	require.NoError(t, err)
+	require.Len(t, got, 5)
+	require.Equal(t, 5, got[4].ID)
+	checkCurrentPage(t, resp, 3)
+}
+
+func TestVirtualMachines_ListAll_maxItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleVirtualMachinePages(t, 5)
+
+	got, resp, err := client.VirtualMachines.ListAll(ctx, &ListAllOptions{PerPage: 2, MaxItems: 3})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	checkCurrentPage(t, resp, 2)
+}
+
+func TestListAllPages_contextCancelled(t *testing.T) {
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	calls := 0
+	_, _, err := ListAllPages(cancelled, nil, func(context.Context, *ListOptions) ([]int, *Response, error) {
+		calls++
+		return []int{1}, nil, nil
+	})
+
+	require.Equal(t, context.Canceled, err)
+	require.Zero(t, calls)
+}
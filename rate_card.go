@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -30,6 +28,8 @@ type RateCardsServiceOp struct {
 
 var _ RateCardsService = &RateCardsServiceOp{}
 
+var rateCardsCRUD = crud[RateCard]{name: "RateCard", path: bucketRateCardsBasePath, root: "rate_card"}
+
 type RateCard struct {
 	BucketID       int     `json:"bucket_id,omitempty"`
 	ServerType     string  `json:"server_type,omitempty"`
@@ -50,10 +50,6 @@ type RateCardCreateRequest struct {
 	Prices                         *Prices `json:"prices,omitempty"`
 }
 
-type rateCardRoot struct {
-	RateCard *RateCard `json:"rate_card"`
-}
-
 type RateCardDeleteRequest RateCardCreateRequest
 
 func (d RateCardCreateRequest) String() string {
@@ -66,25 +62,7 @@ func (s *RateCardsServiceOp) List(ctx context.Context, id int, opt *ListOptions)
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf(bucketRateCardsBasePath, id) + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]RateCard
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]RateCard, len(out))
-	for i := range arr {
-		arr[i] = out[i]["rate_card"]
-	}
-
-	return arr, resp, err
+	return rateCardsCRUD.at(id).list(ctx, s.client, opt)
 }
 
 // Create RateCard.
@@ -93,42 +71,27 @@ func (s *RateCardsServiceOp) Create(ctx context.Context, createRequest *RateCard
 		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(bucketRateCardsBasePath, createRequest.BucketID) + apiFormat
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("RateCard [Create]  req: ", req)
-
-	root := new(rateCardRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.RateCard, resp, err
+	r := rateCardsCRUD.at(createRequest.BucketID)
+	return r.do(ctx, s.client, "Create", http.MethodPost, r.collection(), createRequest)
 }
 
 // Delete RateCard.
 func (s *RateCardsServiceOp) Delete(ctx context.Context, deleteRequest *RateCardDeleteRequest, meta interface{}) (*Response, error) {
-	if deleteRequest.BucketID < 1 {
-		return nil, godo.NewArgError("bucket_id", "cannot be less than 1")
+	if deleteRequest == nil {
+		return nil, godo.NewArgError("deleteRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(bucketRateCardsBasePath, deleteRequest.BucketID) + apiFormat
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
+	if deleteRequest.BucketID < 1 {
+		return nil, godo.NewArgError("bucket_id", "cannot be less than 1")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, deleteRequest)
+	r := rateCardsCRUD.at(deleteRequest.BucketID)
+	path, err := addOptions(r.collection(), meta)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("RateCard [Delete] req: ", req)
 
-	return s.client.Do(ctx, req, nil)
+	return r.send(ctx, s.client, "Delete", http.MethodDelete, path, deleteRequest)
 }
 
 type Prices map[string]interface{}
@@ -0,0 +1,202 @@
+package onappgo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket rate limit and a cap of concurrent
+// requests, zero values disable the respective limit.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the bucket refills.
+	RequestsPerSecond float64
+
+	// Burst is the bucket size, at least 1.
+	Burst int
+
+	// MaxInFlight caps the number of requests waiting for a response.
+	MaxInFlight int
+}
+
+// SetRateLimit is a client option for limiting all requests of the client.
+func SetRateLimit(limit RateLimit) ClientOpt {
+	return func(c *Client) error {
+		c.limiter = newLimiter(limit)
+		return nil
+	}
+}
+
+// SetServiceRateLimit is a client option for limiting the requests of a single
+// service on top of the client limit. The pattern is either the service name,
+// e.g. "disks" for settings/disks, or a path.Match pattern of the templated
+// path, e.g. "virtual_machines/{id}/*" for the VirtualMachineActions. The first
+// matching pattern is applied.
+func SetServiceRateLimit(pattern string, limit RateLimit) ClientOpt {
+	return func(c *Client) error {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return err
+		}
+
+		c.serviceLimiters = append(c.serviceLimiters, serviceLimiter{
+			pattern: pattern,
+			limiter: newLimiter(limit),
+		})
+		return nil
+	}
+}
+
+type serviceLimiter struct {
+	pattern string
+	limiter *limiter
+}
+
+// acquire waits for the client and service limits of the request and returns
+// the function releasing its in-flight slots.
+func (c *Client) acquire(ctx context.Context, req *http.Request) (func(), error) {
+	var limiters []*limiter
+	if c.limiter != nil {
+		limiters = append(limiters, c.limiter)
+	}
+
+	if len(c.serviceLimiters) > 0 {
+		route := c.templatedPath(req)
+		service := serviceName(route)
+
+		for _, sl := range c.serviceLimiters {
+			if ok, _ := path.Match(sl.pattern, route); ok || sl.pattern == service {
+				limiters = append(limiters, sl.limiter)
+				break
+			}
+		}
+	}
+
+	acquired := make([]*limiter, 0, len(limiters))
+	release := func() {
+		for _, l := range acquired {
+			l.release()
+		}
+	}
+
+	for _, l := range limiters {
+		if err := l.acquire(ctx); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, l)
+	}
+
+	return release, nil
+}
+
+type limiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	l := &limiter{}
+
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+
+		l.bucket = &tokenBucket{
+			rate:   limit.RequestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return l
+}
+
+func (l *limiter) acquire(ctx context.Context) error {
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (l *limiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token from the bucket, blocking until one is available or the
+// context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return context.DeadlineExceeded
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// releaseOnClose releases the in-flight slots once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
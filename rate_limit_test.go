@@ -0,0 +1,75 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo_maxInFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRateLimit(RateLimit{MaxInFlight: 2})(client))
+
+	var inFlight, maxInFlight int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if cur <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+			_, err := client.Do(ctx, req, nil)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), maxInFlight)
+}
+
+func TestDo_serviceRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetServiceRateLimit("virtual_machines/{id}/*", RateLimit{RequestsPerSecond: 1})(client))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"virtual_machines.json", "virtual_machines.json", "virtual_machines/1/startup.json"} {
+		req, _ := client.NewRequest(ctx, http.MethodGet, path, nil)
+		_, err := client.Do(ctx, req, nil)
+		require.NoError(t, err)
+	}
+
+	deadline, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "virtual_machines/1/stop.json", nil)
+	_, err := client.Do(deadline, req, nil)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestSetServiceRateLimit_badPattern(t *testing.T) {
+	_, err := New(nil, SetServiceRateLimit("[", RateLimit{RequestsPerSecond: 1}))
+	require.Error(t, err)
+}
@@ -0,0 +1,340 @@
+package onappgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/godo"
+)
+
+// RecorderMode selects what the Recorder does with requests.
+type RecorderMode int
+
+const (
+	// RecorderPassthrough sends requests unchanged and records nothing.
+	RecorderPassthrough RecorderMode = iota
+
+	// RecorderRecord sends requests and writes them with their responses to
+	// the cassette.
+	RecorderRecord
+
+	// RecorderReplay answers requests from the cassette without sending them.
+	RecorderReplay
+)
+
+// ErrInteractionNotFound is returned in replay mode for requests which have no
+// unused recorded interaction in the cassette.
+var ErrInteractionNotFound = errors.New("onappgo: no recorded interaction for request")
+
+// Cassette is the file format of the Recorder.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request with its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an Interaction. Path is relative to the base
+// URL of the client, so that cassettes recorded against one control panel can
+// be replayed against another. Route is the templated path, e.g.
+// "virtual_machines/{id}/startup", it is informational only.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Route  string      `json:"route"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper which records API sessions to a cassette
+// file and replays them, install it with SetRecorder. The Authorization header
+// and secrets such as passwords and API keys are scrubbed before anything is
+// written. In record mode the cassette is written by Close.
+//
+// Requests are matched by method, path, query and body. Identical requests,
+// e.g. polling a transaction, are answered in the recorded order.
+type Recorder struct {
+	mode   RecorderMode
+	path   string
+	client *Client
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder working with the cassette file at path. In
+// replay mode the cassette is loaded, in record mode it is truncated.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+	}
+
+	switch mode {
+	case RecorderReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("onappgo: invalid cassette %s: %w", path, err)
+		}
+
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case RecorderRecord:
+		if err := r.save(); err != nil {
+			return nil, err
+		}
+	case RecorderPassthrough:
+	default:
+		return nil, godo.NewArgError("mode", "unknown recorder mode")
+	}
+
+	return r, nil
+}
+
+// SetRecorder is a client option for sending all requests through the
+// recorder. The recorder wraps the transport of the HTTP client when requests
+// are sent, so the transport options work in any order with it.
+func SetRecorder(r *Recorder) ClientOpt {
+	return func(c *Client) error {
+		if r == nil {
+			return godo.NewArgError("recorder", "cannot be nil")
+		}
+
+		r.client = c
+		c.recorder = r
+		return nil
+	}
+}
+
+// Close writes the cassette in record mode, it does nothing in the others.
+func (r *Recorder) Close() error {
+	if r.mode != RecorderRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.save()
+}
+
+// Cassette returns a copy of the recorded interactions.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	copy(res.Interactions, r.cassette.Interactions)
+
+	return res
+}
+
+// RoundTrip implements http.RoundTripper, requests are sent with
+// http.DefaultTransport.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, http.DefaultTransport)
+}
+
+// recorderTransport sends the requests of a client through its Recorder.
+type recorderTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return t.recorder.roundTrip(req, next)
+}
+
+func (r *Recorder) roundTrip(req *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	switch r.mode {
+	case RecorderRecord:
+		return r.record(req, transport)
+	case RecorderReplay:
+		return r.replay(req)
+	}
+
+	return transport.RoundTrip(req)
+}
+
+func (r *Recorder) record(req *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       string(redactJSON(body)),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !in.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body := in.Response.Body
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.uri())
+}
+
+// recordRequest returns the scrubbed request, the request body is left untouched.
+func (r *Recorder) recordRequest(req *http.Request) (RecordedRequest, error) {
+	var body []byte
+	switch {
+	case req.GetBody != nil:
+		rc, err := req.GetBody()
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+		defer rc.Close()
+
+		if body, err = ioutil.ReadAll(rc); err != nil {
+			return RecordedRequest{}, err
+		}
+	case req.Body != nil && req.Body != http.NoBody:
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+
+		body = data
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	path := req.URL.Path
+	route := strings.TrimSuffix(strings.Trim(path, "/"), apiFormat)
+	if r.client != nil {
+		path = strings.TrimPrefix(path, r.client.BaseURL.Path)
+		route = r.client.templatedPath(req)
+	}
+
+	return RecordedRequest{
+		Method: req.Method,
+		Route:  route,
+		Path:   "/" + strings.TrimPrefix(path, "/"),
+		Query:  scrubQuery(req.URL.Query()).Encode(),
+		Header: scrubHeader(req.Header),
+		Body:   string(redactJSON(body)),
+	}, nil
+}
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method &&
+		rr.Path == other.Path &&
+		rr.Query == other.Query &&
+		rr.Body == other.Body
+}
+
+func (rr RecordedRequest) uri() string {
+	if rr.Query == "" {
+		return rr.Path
+	}
+
+	return rr.Path + "?" + rr.Query
+}
+
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, data, 0600)
+}
+
+// scrubHeader returns a copy of the header without credentials.
+func scrubHeader(h http.Header) http.Header {
+	res := h.Clone()
+	for k := range res {
+		if k == "Authorization" || k == "Cookie" || k == "Set-Cookie" || sensitiveKey(k) {
+			res.Set(k, redacted)
+		}
+	}
+
+	return res
+}
+
+// scrubQuery returns a copy of the query with secrets redacted.
+func scrubQuery(q url.Values) url.Values {
+	res := make(url.Values, len(q))
+	for k, v := range q {
+		if sensitiveKey(k) {
+			v = []string{redacted}
+		}
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,124 @@
+package onappgo
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"user":{"id":7,"login":"jdoe","api_key":"0123456789"}}`)
+	})
+	mux.HandleFunc("/virtual_machines.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerPage, r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `[{"virtual_machine":{"id":%s,"label":"vm"}}]`, r.URL.Query().Get("page"))
+	})
+
+	cassette := filepath.Join(t.TempDir(), "session.json")
+
+	rec, err := NewRecorder(cassette, RecorderRecord)
+	require.NoError(t, err)
+	require.NoError(t, SetRecorder(rec)(client))
+
+	user, _, err := client.Users.Create(ctx, &UserCreateRequest{Login: "jdoe", Password: "hunter2"})
+	require.NoError(t, err)
+	require.Equal(t, 7, user.ID)
+
+	for page := 1; page <= 2; page++ {
+		_, _, err = client.VirtualMachines.List(ctx, &ListOptions{Page: page, PerPage: 1})
+		require.NoError(t, err)
+	}
+
+	data, err := ioutil.ReadFile(cassette)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "jdoe")
+
+	require.NoError(t, rec.Close())
+
+	data, err = ioutil.ReadFile(cassette)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "jdoe")
+	require.NotContains(t, string(data), "hunter2")
+	require.NotContains(t, string(data), "0123456789")
+	require.NotContains(t, string(data), "Basic ")
+
+	recorded := rec.Cassette()
+	require.Len(t, recorded.Interactions, 3)
+	require.Equal(t, "users", recorded.Interactions[0].Request.Route)
+	require.Equal(t, "/users.json", recorded.Interactions[0].Request.Path)
+	require.Equal(t, "page=2&per_page=1", recorded.Interactions[2].Request.Query)
+
+	replayer, err := NewRecorder(cassette, RecorderReplay)
+	require.NoError(t, err)
+
+	replayed, err := New(nil, SetBaseURL("http://replay.invalid"), SetBasicAuth(email, "other"), SetRecorder(replayer))
+	require.NoError(t, err)
+
+	vms, resp, err := replayed.VirtualMachines.List(ctx, &ListOptions{Page: 2, PerPage: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, vms[0].ID)
+	require.Equal(t, 2, resp.Links.CurPage)
+
+	_, _, err = replayed.Users.Create(ctx, &UserCreateRequest{Login: "jdoe", Password: "another"})
+	require.NoError(t, err)
+
+	_, _, err = replayed.VirtualMachines.List(ctx, &ListOptions{Page: 2, PerPage: 1})
+	require.True(t, errors.Is(err, ErrInteractionNotFound))
+	require.True(t, strings.Contains(err.Error(), "page=2&per_page=1"))
+}
+
+func TestRecorder_passthrough(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec, err := NewRecorder(filepath.Join(t.TempDir(), "unused.json"), RecorderPassthrough)
+	require.NoError(t, err)
+
+	hc := &http.Client{}
+	c, err := New(hc, SetBaseURL(server.URL), SetRecorder(rec))
+	require.NoError(t, err)
+	require.Nil(t, hc.Transport)
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err = c.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Empty(t, rec.Cassette().Interactions)
+}
+
+func TestRecorder_transportOptions(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"virtual_machine":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	cassette := filepath.Join(t.TempDir(), "session.json")
+	rec, err := NewRecorder(cassette, RecorderRecord)
+	require.NoError(t, err)
+
+	c, err := New(nil, SetBaseURL(srv.URL), SetRecorder(rec), SetAllowUnverifiedSSL(true))
+	require.NoError(t, err)
+	require.True(t, c.transport.TLSClientConfig.InsecureSkipVerify)
+
+	vm, _, err := c.VirtualMachines.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, vm.ID)
+
+	require.NoError(t, rec.Close())
+	require.Len(t, rec.Cassette().Interactions, 1)
+}
@@ -2,8 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 const remoteTemplatesBasePath string = "templates/available"
@@ -15,6 +13,7 @@ const remoteTemplatesBasePath string = "templates/available"
 // Describe templates *available* for install on the OnApp repository
 type RemoteTemplatesService interface {
 	List(context.Context, *ListOptions) ([]RemoteTemplate, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]RemoteTemplate, *Response, error)
 }
 
 // RemoteTemplatesServiceOp handles communication with the RemoteTemplate related methods of the
@@ -25,6 +24,8 @@ type RemoteTemplatesServiceOp struct {
 
 var _ RemoteTemplatesService = &RemoteTemplatesServiceOp{}
 
+var remoteTemplatesCRUD = crud[RemoteTemplate]{name: "RemoteTemplate", path: remoteTemplatesBasePath, root: "remote_template"}
+
 // RemoteTemplate - represent a template of OnApp API from repository
 type RemoteTemplate struct {
 	AllowResizeWithoutReboot  bool   `json:"allow_resize_without_reboot,bool"`
@@ -55,28 +56,10 @@ type RemoteTemplate struct {
 
 // List all RemoteTemplates.
 func (s *RemoteTemplatesServiceOp) List(ctx context.Context, opt *ListOptions) ([]RemoteTemplate, *Response, error) {
-	path := remoteTemplatesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("RemoteTemplate [List] req: ", req)
-
-	var out []map[string]RemoteTemplate
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]RemoteTemplate, len(out))
-	for i := range arr {
-		arr[i] = out[i]["remote_template"]
-	}
+	return remoteTemplatesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of RemoteTemplates.
+func (s *RemoteTemplatesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]RemoteTemplate, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
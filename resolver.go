@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const resolverBasePath string = "settings/nameservers"
 // https://docs.onapp.com/apim/latest/firewall-rules-for-vss
 type ResolversService interface {
 	List(context.Context, *ListOptions) ([]Resolver, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Resolver, *Response, error)
 	Get(context.Context, int) (*Resolver, *Response, error)
 	Create(context.Context, *ResolverCreateRequest) (*Resolver, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type ResolversServiceOp struct {
 
 var _ ResolversService = &ResolversServiceOp{}
 
+var resolversCRUD = crud[Resolver]{name: "Resolver", path: resolverBasePath, root: "nameserver"}
+
 // Resolver -
 // https://docs.onapp.com/apim/latest/resolvers
 type Resolver struct {
@@ -46,130 +46,36 @@ type ResolverCreateRequest struct {
 	NetworkID int    `json:"network_id,omitempty"`
 }
 
-type resolverCreateRequestRoot struct {
-	ResolverCreateRequest *ResolverCreateRequest `json:"nameserver"`
-}
-
-type resolverRoot struct {
-	Resolver *Resolver `json:"nameserver"`
-}
-
 func (d ResolverCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Resolvers
 func (s *ResolversServiceOp) List(ctx context.Context, opt *ListOptions) ([]Resolver, *Response, error) {
-	path := resolverBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Resolver
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Resolver, len(out))
-	for i := range arr {
-		arr[i] = out[i]["nameserver"]
-	}
-
-	return arr, resp, err
+	return resolversCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of Resolvers.
+func (s *ResolversServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Resolver, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Resolver
 func (s *ResolversServiceOp) Get(ctx context.Context, id int) (*Resolver, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", resolverBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(resolverRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Resolver, resp, err
+	return resolversCRUD.get(ctx, s.client, id)
 }
 
 // Create Resolver
 func (s *ResolversServiceOp) Create(ctx context.Context, createRequest *ResolverCreateRequest) (*Resolver, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("Resolver createRequest", "cannot be nil")
-	}
-
-	path := resolverBasePath + apiFormat
-	rootRequest := &resolverCreateRequestRoot{
-		ResolverCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("Resolver [Create] req: ", req)
-
-	root := new(resolverRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Resolver, resp, err
+	return resolversCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete Resolver
 func (s *ResolversServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("vmID || id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", resolverBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	fmt.Println("Resolver [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return resolversCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Resolver
 func (s *ResolversServiceOp) Edit(ctx context.Context, id int, editRequest *ResolverCreateRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("Resolver [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", resolverBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Resolver [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return resolversCRUD.edit(ctx, s.client, id, editRequest)
 }
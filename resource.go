@@ -0,0 +1,188 @@
+package onappgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+)
+
+// The resource helpers call endpoints which have no service yet with the same
+// requests, errors and options as the services. Paths are relative to the
+// base URL, including the format, e.g. "settings/hypervisor_zones/1.json".
+// Root is the key the OnApp API wraps objects and create requests in, e.g.
+// "hypervisor_group", an empty root means the objects are not wrapped. Like
+// the Edit requests of the services, update requests are sent as is.
+//
+//	zone, _, err := onappgo.GetResource[onappgo.HypervisorGroup](ctx, client,
+//		"settings/hypervisor_zones/1.json", "hypervisor_group")
+
+// GetResource fetches a single object.
+func GetResource[T any](ctx context.Context, c *Client, path, root string) (*T, *Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.debugRequest(fmt.Sprintf("Resource [Get %s]", root), req)
+
+	return doResource[T](ctx, c, req, root)
+}
+
+// ListResources fetches a single page of objects.
+func ListResources[T any](ctx context.Context, c *Client, path, root string, opt *ListOptions) ([]T, *Response, error) {
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.debugRequest(fmt.Sprintf("Resource [List %s]", root), req)
+
+	return doResources[T](ctx, c, req, root)
+}
+
+// ListAllResources fetches all pages of objects, see ListAllPages.
+func ListAllResources[T any](ctx context.Context, c *Client, path, root string, opts *ListAllOptions) ([]T, *Response, error) {
+	return ListAllPages(ctx, opts, func(ctx context.Context, opt *ListOptions) ([]T, *Response, error) {
+		return ListResources[T](ctx, c, path, root, opt)
+	})
+}
+
+// CreateResource posts the request wrapped in root and returns the created
+// object, nil if the response has no body.
+func CreateResource[T any](ctx context.Context, c *Client, path, root string, createRequest interface{}) (*T, *Response, error) {
+	if isNil(createRequest) {
+		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, path, wrapResource(root, createRequest))
+	if err != nil {
+		return nil, nil, err
+	}
+	c.debugRequest(fmt.Sprintf("Resource [Create %s]", root), req)
+
+	return doResource[T](ctx, c, req, root)
+}
+
+// UpdateResource puts the request as is, root only names it in the debug log.
+func UpdateResource(ctx context.Context, c *Client, path, root string, editRequest interface{}) (*Response, error) {
+	if isNil(editRequest) {
+		return nil, godo.NewArgError("editRequest", "cannot be nil")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPut, path, editRequest)
+	if err != nil {
+		return nil, err
+	}
+	c.debugRequest(fmt.Sprintf("Resource [Update %s]", root), req)
+
+	return c.Do(ctx, req, nil)
+}
+
+// DeleteResource deletes the object, meta is encoded into the query.
+func DeleteResource(ctx context.Context, c *Client, path string, meta interface{}) (*Response, error) {
+	path, err := addOptions(path, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.debugRequest("Resource [Delete]", req)
+
+	return c.Do(ctx, req, nil)
+}
+
+func doResource[T any](ctx context.Context, c *Client, req *http.Request, root string) (*T, *Response, error) {
+	var buf bytes.Buffer
+	resp, err := c.Do(ctx, req, &buf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil, resp, nil
+	}
+
+	data := buf.Bytes()
+	if root != "" {
+		var out map[string]json.RawMessage
+		if err := unmarshalResource(data, &out); err != nil {
+			return nil, resp, err
+		}
+
+		var ok bool
+		if data, ok = out[root]; !ok {
+			return nil, resp, fmt.Errorf("onappgo: no %q in response", root)
+		}
+	}
+
+	res := new(T)
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, resp, err
+	}
+
+	return res, resp, nil
+}
+
+// doResources decodes the list of objects of the response.
+func doResources[T any](ctx context.Context, c *Client, req *http.Request, root string) ([]T, *Response, error) {
+	var buf bytes.Buffer
+	resp, err := c.Do(ctx, req, &buf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if root == "" {
+		var out []T
+		if err := unmarshalResource(buf.Bytes(), &out); err != nil {
+			return nil, resp, err
+		}
+
+		return out, resp, nil
+	}
+
+	var out []map[string]json.RawMessage
+	if err := unmarshalResource(buf.Bytes(), &out); err != nil {
+		return nil, resp, err
+	}
+
+	lst := make([]T, len(out))
+	for i := range out {
+		data, ok := out[i][root]
+		if !ok {
+			return nil, resp, fmt.Errorf("onappgo: no %q in list item %d", root, i)
+		}
+
+		if err := json.Unmarshal(data, &lst[i]); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	return lst, resp, nil
+}
+
+func wrapResource(root string, v interface{}) interface{} {
+	if root == "" {
+		return v
+	}
+
+	return map[string]interface{}{root: v}
+}
+
+// unmarshalResource decodes data, an empty body is an empty result.
+func unmarshalResource(data []byte, v interface{}) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,89 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testWidget struct {
+	ID    int    `json:"id,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+func TestResources(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/widgets.json", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			page := r.URL.Query().Get("page")
+			w.Header().Set(headerPage, page)
+			w.Header().Set(headerPerPage, "1")
+			w.Header().Set(headerTotal, "2")
+			fmt.Fprintf(w, `[{"widget":{"id":%s,"label":"w%s"}}]`, page, page)
+		case http.MethodPost:
+			var body map[string]testWidget
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, "new", body["widget"].Label)
+
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"widget":{"id":3,"label":"new"}}`)
+		}
+	})
+	mux.HandleFunc("/widgets/3.json", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"widget":{"id":3,"label":"new"}}`)
+		case http.MethodPut:
+			var body testWidget
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, "renamed", body.Label)
+
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			require.Equal(t, "1", r.URL.Query().Get("force"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	widgets, resp, err := ListResources[testWidget](ctx, client, "widgets.json", "widget", &ListOptions{Page: 2, PerPage: 1})
+	require.NoError(t, err)
+	require.Equal(t, []testWidget{{ID: 2, Label: "w2"}}, widgets)
+	require.Equal(t, 2, resp.Links.NumPages)
+
+	widgets, _, err = ListAllResources[testWidget](ctx, client, "widgets.json", "widget", &ListAllOptions{PerPage: 1})
+	require.NoError(t, err)
+	require.Len(t, widgets, 2)
+
+	created, _, err := CreateResource[testWidget](ctx, client, "widgets.json", "widget", &testWidget{Label: "new"})
+	require.NoError(t, err)
+	require.Equal(t, 3, created.ID)
+
+	widget, _, err := GetResource[testWidget](ctx, client, "widgets/3.json", "widget")
+	require.NoError(t, err)
+	require.Equal(t, "new", widget.Label)
+
+	_, err = UpdateResource(ctx, client, "widgets/3.json", "widget", &testWidget{Label: "renamed"})
+	require.NoError(t, err)
+
+	_, err = DeleteResource(ctx, client, "widgets/3.json", &struct {
+		Force int `url:"force"`
+	}{Force: 1})
+	require.NoError(t, err)
+
+	_, _, err = GetResource[testWidget](ctx, client, "widgets/4.json", "widget")
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	var widgetRequest *testWidget
+	_, _, err = CreateResource[testWidget](ctx, client, "widgets.json", "widget", widgetRequest)
+	require.EqualError(t, err, "createRequest is invalid because cannot be nil")
+
+	_, err = UpdateResource(ctx, client, "widgets/3.json", "widget", widgetRequest)
+	require.EqualError(t, err, "editRequest is invalid because cannot be nil")
+}
@@ -0,0 +1,246 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy describes how Client.Do retries requests which failed with a
+// transient error: a reset connection, 429, 502, 503 or 504 status code.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, 1 second by default.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff, 30 seconds by default.
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent allows to retry POST and PATCH requests as well,
+	// which may repeat the action on the OnApp side.
+	RetryNonIdempotent bool
+}
+
+// SetRetryPolicy is a client option for retrying transient API errors with
+// exponential backoff and jitter.
+func SetRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = defaultRetryMinBackoff
+		}
+
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+			if policy.MaxBackoff < policy.MinBackoff {
+				policy.MaxBackoff = policy.MinBackoff
+			}
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// doWithRetry sends the request until it succeeds, fails with a permanent error
+// or the retry policy is exhausted. It returns the number of attempts made.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, int, error) {
+	attempt := 0
+	staleRetried := false
+	for {
+		attempt++
+
+		release, err := c.acquire(ctx, req)
+		if err != nil {
+			return nil, attempt - 1, err
+		}
+
+		var reused int32
+		trace := &httptrace.ClientTrace{
+			GotConn: func(info httptrace.GotConnInfo) {
+				if info.Reused {
+					atomic.StoreInt32(&reused, 1)
+				}
+			},
+		}
+
+		resp, err := c.roundTrip(req.WithContext(httptrace.WithClientTrace(ctx, trace)))
+		if err != nil {
+			release()
+		} else {
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+		}
+
+		// The control panel may close an idle connection just as a request is
+		// sent on it. net/http resends requests it did not write, but once
+		// written the request may have reached the application before the
+		// connection dropped. So it is sent once more on a fresh connection
+		// only if repeating it is safe.
+		if err != nil && !staleRetried && atomic.LoadInt32(&reused) == 1 &&
+			isStaleConnectionError(err) && replayable(req) && ctx.Err() == nil &&
+			(idempotent(req.Method) || (c.retryPolicy != nil && c.retryPolicy.RetryNonIdempotent)) {
+			staleRetried = true
+			c.client.CloseIdleConnections()
+
+			if err := rewindBody(req); err != nil {
+				return nil, attempt, err
+			}
+			continue
+		}
+
+		policy := c.retryPolicy
+		if policy == nil || attempt > policy.MaxRetries || !policy.retryable(ctx, req, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay := policy.backoff(attempt, resp)
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, attempt, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(ctx context.Context, req *http.Request, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if !replayable(req) {
+		return false
+	}
+
+	if !idempotent(req.Method) && !p.RetryNonIdempotent {
+		return false
+	}
+
+	if err != nil {
+		return isTransientError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the delay before the next attempt. Retry-After sent by the
+// control panel takes precedence over the computed delay.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+
+	delay := p.MinBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	// equal jitter keeps at least half of the delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// idempotent reports whether sending a request with the method twice has the
+// same effect as sending it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// replayable reports whether the request body can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindBody resets the request body before the request is sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
+
+// isStaleConnectionError reports errors of writing to or reading from a
+// connection which was closed by the server.
+func isStaleConnectionError(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
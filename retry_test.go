@@ -0,0 +1,115 @@
+package onappgo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo_retryTransientStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond})(client))
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	resp, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, resp.Attempts)
+	require.Equal(t, 3, calls)
+}
+
+func TestDo_retryExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRetryPolicy(RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond})(client))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodDelete, "/", nil)
+	resp, err := client.Do(ctx, req, nil)
+	require.Error(t, err)
+	require.Equal(t, 2, resp.Attempts)
+}
+
+func TestDo_retryTransportError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRetryPolicy(RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond})(client))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	resp, err := client.Do(ctx, req, nil)
+	require.Error(t, err)
+	require.Nil(t, resp.Response)
+	require.Equal(t, 3, resp.Attempts)
+}
+
+func TestDo_retrySkipsPOST(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond})(client))
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", map[string]string{"a": "b"})
+	resp, err := client.Do(ctx, req, nil)
+	require.Error(t, err)
+	require.Equal(t, 1, resp.Attempts)
+	require.Equal(t, 1, calls)
+}
+
+func TestDo_retryNonIdempotentResendsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	require.NoError(t, SetRetryPolicy(RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond, RetryNonIdempotent: true})(client))
+
+	var bodies []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, StreamToString(r.Body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", map[string]string{"a": "b"})
+	_, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"{\"a\":\"b\"}\n", "{\"a\":\"b\"}\n"}, bodies)
+}
+
+func TestRetryAfter(t *testing.T) {
+	delay, ok := retryAfter("7")
+	require.True(t, ok)
+	require.Equal(t, 7*time.Second, delay)
+
+	_, ok = retryAfter("soon")
+	require.False(t, ok)
+}
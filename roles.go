@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const rolesBasePath string = "roles"
 // https://docs.onapp.com/apim/latest/roles
 type RolesService interface {
 	List(context.Context, *ListOptions) ([]Role, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Role, *Response, error)
 	Get(context.Context, int) (*Role, *Response, error)
 	Create(context.Context, *RoleCreateRequest) (*Role, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type RolesServiceOp struct {
 
 var _ RolesService = &RolesServiceOp{}
 
+var rolesCRUD = crud[Role]{name: "Role", path: rolesBasePath, root: "role"}
+
 // Permission -
 type Permission struct {
 	ID         int    `json:"id,omitempty"`
@@ -62,134 +62,36 @@ type RoleCreateRequest struct {
 	PermissionIds []int  `json:"permission_ids,omitempty"`
 }
 
-type roleCreateRequestRoot struct {
-	RoleCreateRequest *RoleCreateRequest `json:"role"`
-}
-
-type roleRoot struct {
-	Role *Role `json:"role"`
-}
-
 func (d RoleCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Roles.
 func (s *RolesServiceOp) List(ctx context.Context, opt *ListOptions) ([]Role, *Response, error) {
-	path := rolesBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Role
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Role, len(out))
-	for i := range arr {
-		arr[i] = out[i]["role"]
-	}
-
-	return arr, resp, err
+	return rolesCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of Roles.
+func (s *RolesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Role, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Role.
 func (s *RolesServiceOp) Get(ctx context.Context, id int) (*Role, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", rolesBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(roleRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Role, resp, err
+	return rolesCRUD.get(ctx, s.client, id)
 }
 
 // Create Role.
 func (s *RolesServiceOp) Create(ctx context.Context, createRequest *RoleCreateRequest) (*Role, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("Role createRequest", "cannot be nil")
-	}
-
-	path := rolesBasePath + apiFormat
-	rootRequest := &roleCreateRequestRoot{
-		RoleCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("Role [Create] req: ", req)
-
-	root := new(roleRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Role, resp, err
+	return rolesCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete Role.
 func (s *RolesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", rolesBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	fmt.Println("Role [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return rolesCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Role
 func (s *RolesServiceOp) Edit(ctx context.Context, id int, editRequest *RoleCreateRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("Role editRequest", "cannot be nil")
-	}
-
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", rolesBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Role [Edit] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return rolesCRUD.edit(ctx, s.client, id, editRequest)
 }
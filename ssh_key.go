@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -17,6 +14,7 @@ const addSSHKeyBasePath string = "users/%d/ssh_keys"
 // https://docs.onapp.com/apim/latest/ssh-keys
 type SSHKeysService interface {
 	List(context.Context, *ListOptions) ([]SSHKey, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]SSHKey, *Response, error)
 	Get(context.Context, int) (*SSHKey, *Response, error)
 	Create(context.Context, *SSHKeyCreateRequest) (*SSHKey, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -31,6 +29,8 @@ type SSHKeysServiceOp struct {
 
 var _ SSHKeysService = &SSHKeysServiceOp{}
 
+var sshKeysCRUD = crud[SSHKey]{name: "SSHKey", path: sshKeyBasePath, root: "ssh_key"}
+
 // SSHKey - represent disk from Virtual Machine
 type SSHKey struct {
 	ID        int    `json:"id,omitempty"`
@@ -52,65 +52,23 @@ type SSHKeyEditRequest struct {
 	Key    string `json:"key,omitempty"`
 }
 
-type sshKeyCreateRequestRoot struct {
-	SSHKeyCreateRequest *SSHKeyCreateRequest `json:"ssh_key"`
-}
-
-type sshKeyRoot struct {
-	SSHKey *SSHKey `json:"ssh_key"`
-}
-
 func (d SSHKeyCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all SSH Keys in the cloud.
 func (s *SSHKeysServiceOp) List(ctx context.Context, opt *ListOptions) ([]SSHKey, *Response, error) {
-	path := sshKeyBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]SSHKey
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]SSHKey, len(out))
-	for i := range arr {
-		arr[i] = out[i]["ssh_key"]
-	}
+	return sshKeysCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of SSHKeys.
+func (s *SSHKeysServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]SSHKey, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual SSH key.
 func (s *SSHKeysServiceOp) Get(ctx context.Context, id int) (*SSHKey, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", sshKeyBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(sshKeyRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.SSHKey, resp, err
+	return sshKeysCRUD.get(ctx, s.client, id)
 }
 
 // Create SSHKey.
@@ -119,62 +77,15 @@ func (s *SSHKeysServiceOp) Create(ctx context.Context, createRequest *SSHKeyCrea
 		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
 	}
 
-	path := fmt.Sprintf(addSSHKeyBasePath, createRequest.UserID) + apiFormat
-
-	rootRequest := &sshKeyCreateRequestRoot{
-		SSHKeyCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("SSHKey [Create]  req: ", req)
-
-	root := new(sshKeyRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.SSHKey, resp, err
+	return sshKeysCRUD.in(addSSHKeyBasePath, createRequest.UserID).create(ctx, s.client, createRequest)
 }
 
 // Delete SSHKey.
 func (s *SSHKeysServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", sshKeyBasePath, id, apiFormat)
-
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("SSHKey [Delete]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return sshKeysCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit SSHKey.
 func (s *SSHKeysServiceOp) Edit(ctx context.Context, id int, editRequest *SSHKeyEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", sshKeyBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("SSHKey [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return sshKeysCRUD.edit(ctx, s.client, id, editRequest)
 }
@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -25,6 +24,7 @@ const hypervisorRebootBasePath string = hypervisorsBasePath + "/%d/reboot"
 // See: https://docs.onapp.com/apim/latest/compute-resources
 type HypervisorsService interface {
 	List(context.Context, *ListOptions) ([]Hypervisor, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Hypervisor, *Response, error)
 	Get(context.Context, int) (*Hypervisor, *Response, error)
 	Create(context.Context, *HypervisorCreateRequest) (*Hypervisor, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -46,6 +46,8 @@ type HypervisorsServiceOp struct {
 
 var _ HypervisorsService = &HypervisorsServiceOp{}
 
+var hypervisorsCRUD = crud[Hypervisor]{name: "Hypervisor", path: hypervisorsBasePath, root: "hypervisor"}
+
 // Hypervisor represent Hypervisor of the OnApp API
 type Hypervisor struct {
 	AllowUnsafeAssignedInterrupts    bool              `json:"allow_unsafe_assigned_interrupts,bool"`
@@ -145,128 +147,38 @@ type HypervisorEditRequest struct {
 	CPUUnits          int    `json:"cpu_units,omitempty"`
 }
 
-type hypervisorCreateRequestRoot struct {
-	HypervisorCreateRequest *HypervisorCreateRequest `json:"hypervisor"`
-}
-
-type hypervisorRoot struct {
-	Hypervisor *Hypervisor `json:"hypervisor"`
-}
-
 func (d HypervisorCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Hypervisors.
 func (s *HypervisorsServiceOp) List(ctx context.Context, opt *ListOptions) ([]Hypervisor, *Response, error) {
-	path := hypervisorsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Hypervisor
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]Hypervisor, len(out))
-	for i := range arr {
-		arr[i] = out[i]["hypervisor"]
-	}
+	return hypervisorsCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of Hypervisors.
+func (s *HypervisorsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Hypervisor, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual Hypervisor.
 func (s *HypervisorsServiceOp) Get(ctx context.Context, id int) (*Hypervisor, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(hypervisorRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Hypervisor, resp, err
+	return hypervisorsCRUD.get(ctx, s.client, id)
 }
 
 // Create Hypervisor.
 func (s *HypervisorsServiceOp) Create(ctx context.Context, createRequest *HypervisorCreateRequest) (*Hypervisor, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("Hypervisor createRequest", "cannot be nil")
-	}
-
-	path := hypervisorsBasePath + apiFormat
-	rootRequest := &hypervisorCreateRequestRoot{
-		HypervisorCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("Hypervisor [Create] req: ", req)
-
-	root := new(hypervisorRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.Hypervisor, resp, err
+	return hypervisorsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete Hypervisor.
 func (s *HypervisorsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Hypervisor [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return hypervisorsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit Hypervisor.
 func (s *HypervisorsServiceOp) Edit(ctx context.Context, id int, editRequest *HypervisorEditRequest) (*Response, error) {
-	if editRequest == nil || id < 1 {
-		return nil, godo.NewArgError("editRequest || id", "cannot be nil or less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", hypervisorsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("Hypervisor [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return hypervisorsCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 type HypervisorRebootRequest struct {
@@ -287,7 +199,7 @@ func (s *HypervisorsServiceOp) Reboot(ctx context.Context, id int, rebootRequest
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Hypervisor [Reboot] req: ", req)
+	s.client.debugRequest("Hypervisor [Reboot]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -367,7 +279,7 @@ func (s *HypervisorsServiceOp) Refresh(ctx context.Context, resID int) (*Hardwar
 	key, _ := uuid.NewRandom()
 	req.Header.Add("X-Idempotency-Key", key.String())
 
-	log.Println("Hypervisor [Refresh] req: ", req)
+	s.client.debugRequest("Hypervisor [Refresh]", req)
 
 	out := &rootHardware{}
 	resp, err := s.client.Do(ctx, req, out)
@@ -433,7 +345,7 @@ func (s *HypervisorsServiceOp) Attach(ctx context.Context, resID int, attachRequ
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Hypervisor [Attach] req: ", req)
+	s.client.debugRequest("Hypervisor [Attach]", req)
 
 	resp, err := s.client.Do(ctx, req, nil)
 	if err != nil {
@@ -491,7 +403,7 @@ func (s *HypervisorsServiceOp) EditIntegratedStorageSettings(ctx context.Context
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Hypervisor [EditIntegratedStorageSettings]  req: ", req)
+	s.client.debugRequest("Hypervisor [EditIntegratedStorageSettings]", req)
 
 	return s.client.Do(ctx, req, nil)
 }
@@ -0,0 +1,164 @@
+package onappgo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// ErrPublicKeyPinMismatch is returned for connections to servers whose
+// certificate chain contains none of the pinned public keys.
+var ErrPublicKeyPinMismatch = errors.New("onappgo: no pinned public key in the server certificate chain")
+
+// SetRootCAFiles is a client option for verifying the control panel
+// certificate against the CA certificates in the PEM files instead of the
+// system roots. Repeated use adds to the pool.
+func SetRootCAFiles(files ...string) ClientOpt {
+	return func(c *Client) error {
+		if len(files) == 0 {
+			return godo.NewArgError("files", "cannot be empty")
+		}
+
+		var pems [][]byte
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+
+			pems = append(pems, data)
+		}
+
+		return c.configureTLS(func(cfg *tls.Config) error {
+			if cfg.RootCAs == nil {
+				cfg.RootCAs = x509.NewCertPool()
+			}
+
+			for i, data := range pems {
+				if !cfg.RootCAs.AppendCertsFromPEM(data) {
+					return fmt.Errorf("onappgo: no certificates in %s", files[i])
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+// SetClientCertificate is a client option for authenticating the TLS
+// connection with the PEM encoded certificate and key.
+func SetClientCertificate(certFile, keyFile string) ClientOpt {
+	return func(c *Client) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+
+		return c.setCertificate(cert)
+	}
+}
+
+// SetMinTLSVersion is a client option for the minimum TLS version, e.g.
+// tls.VersionTLS12.
+func SetMinTLSVersion(version uint16) ClientOpt {
+	return func(c *Client) error {
+		switch version {
+		case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+		default:
+			return godo.NewArgError("version", "unknown TLS version")
+		}
+
+		return c.configureTLS(func(cfg *tls.Config) error {
+			cfg.MinVersion = version
+			return nil
+		})
+	}
+}
+
+// SetPinnedPublicKeys is a client option for public key pinning. Pins are the
+// base64 encoded SHA-256 digests of the DER encoded SubjectPublicKeyInfo, with
+// an optional "sha256/" prefix, see PublicKeyPin. Connections succeed when any
+// certificate of the server chain matches any pin. Pinning is checked on top
+// of the regular verification, and also when SetAllowUnverifiedSSL is used.
+// Repeated use adds pins.
+func SetPinnedPublicKeys(pins ...string) ClientOpt {
+	return func(c *Client) error {
+		if len(pins) == 0 {
+			return godo.NewArgError("pins", "cannot be empty")
+		}
+
+		digests := make([][]byte, 0, len(pins))
+		for _, pin := range pins {
+			digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, "sha256/"))
+			if err != nil || len(digest) != sha256.Size {
+				return godo.NewArgError("pins", fmt.Sprintf("invalid SHA-256 pin %q", pin))
+			}
+
+			digests = append(digests, digest)
+		}
+
+		return c.configureTLS(func(cfg *tls.Config) error {
+			if len(c.pins) == 0 {
+				cfg.VerifyConnection = chainVerifyConnection(cfg.VerifyConnection, c.verifyPins)
+			}
+
+			c.pins = append(c.pins, digests...)
+			return nil
+		})
+	}
+}
+
+// PublicKeyPin returns the pin of the certificate public key for SetPinnedPublicKeys.
+func PublicKeyPin(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(digest[:])
+}
+
+func (c *Client) verifyPins(cs tls.ConnectionState) error {
+	for _, cert := range cs.PeerCertificates {
+		digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+		for _, pin := range c.pins {
+			if bytes.Equal(digest[:], pin) {
+				return nil
+			}
+		}
+	}
+
+	return ErrPublicKeyPinMismatch
+}
+
+func chainVerifyConnection(first, second func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	if first == nil {
+		return second
+	}
+
+	return func(cs tls.ConnectionState) error {
+		if err := first(cs); err != nil {
+			return err
+		}
+
+		return second(cs)
+	}
+}
+
+// configureTLS applies fn to the TLS configuration of the client transport,
+// see configureTransport.
+func (c *Client) configureTLS(fn func(*tls.Config) error) error {
+	return c.configureTransport(func(t *http.Transport) error {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+
+		return fn(t.TLSClientConfig)
+	})
+}
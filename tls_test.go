@@ -0,0 +1,87 @@
+package onappgo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSServer starts a TLS server and writes its certificate and key to PEM files.
+func newTLSServer(t *testing.T, handler http.HandlerFunc) (srv *httptest.Server, certFile, keyFile string) {
+	srv = httptest.NewUnstartedServer(handler)
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	cert := srv.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600))
+
+	return srv, certFile, keyFile
+}
+
+func getRoot(c *Client) error {
+	req, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := c.Do(ctx, req, nil)
+	return err
+}
+
+func TestTLS_rootCAAndClientCertificate(t *testing.T) {
+	var peerCerts int
+	srv, certFile, keyFile := newTLSServer(t, func(w http.ResponseWriter, r *http.Request) {
+		peerCerts = len(r.TLS.PeerCertificates)
+	})
+
+	c, err := New(nil, SetBaseURL(srv.URL))
+	require.NoError(t, err)
+	require.Error(t, getRoot(c))
+
+	c, err = New(nil, SetBaseURL(srv.URL),
+		SetRootCAFiles(certFile),
+		SetClientCertificate(certFile, keyFile),
+		SetMinTLSVersion(tls.VersionTLS12),
+		SetAllowUnverifiedSSL(false))
+	require.NoError(t, err)
+	require.NoError(t, getRoot(c))
+	require.Equal(t, 1, peerCerts)
+	require.NotNil(t, c.certificate())
+	require.Equal(t, uint16(tls.VersionTLS12), c.transport.TLSClientConfig.MinVersion)
+	require.Nil(t, http.DefaultClient.Transport)
+}
+
+func TestTLS_pinningWithCallerClient(t *testing.T) {
+	srv, _, _ := newTLSServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	caller := srv.Client()
+	callerTransport := caller.Transport.(*http.Transport)
+
+	pin := PublicKeyPin(srv.Certificate())
+
+	c, err := New(caller, SetBaseURL(srv.URL), SetPinnedPublicKeys(pin))
+	require.NoError(t, err)
+	require.NoError(t, getRoot(c))
+	require.Nil(t, callerTransport.TLSClientConfig.VerifyConnection)
+
+	other := "sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+	c, err = New(srv.Client(), SetBaseURL(srv.URL), SetPinnedPublicKeys(other))
+	require.NoError(t, err)
+	require.True(t, errors.Is(getRoot(c), ErrPublicKeyPinMismatch))
+
+	_, err = New(nil, SetPinnedPublicKeys("not a pin"))
+	require.Error(t, err)
+}
@@ -2,9 +2,11 @@ package onappgo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/digitalocean/godo"
 )
@@ -26,16 +28,23 @@ const (
 
 	// TransactionFailed is a failed transaction status
 	TransactionFailed = "failed"
+
+	defaultWaitInterval    = 5 * time.Second
+	defaultWaitMaxInterval = time.Minute
 )
 
 // TransactionsService handles communction with action related methods of the
 // OnApp API: https://docs.onapp.com/apim/latest/transactions
 type TransactionsService interface {
 	List(context.Context, *ListOptions) ([]Transaction, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]Transaction, *Response, error)
 	Get(context.Context, int) (*Transaction, *Response, error)
 
 	GetByFilter(context.Context, interface{}, *ListOptions) (*Transaction, *Response, error)
 	ListByGroup(context.Context, interface{}, bool, *ListOptions) ([]Transaction, *Response, error)
+	ListByFilter(context.Context, *TransactionFilter) ([]Transaction, *Response, error)
+
+	Wait(context.Context, int, *TransactionWaitOptions) (*Transaction, *Response, error)
 }
 
 // TransactionsServiceOp handles communition with the image action related methods of the
@@ -46,6 +55,8 @@ type TransactionsServiceOp struct {
 
 var _ TransactionsService = &TransactionsServiceOp{}
 
+var transactionsCRUD = crud[Transaction]{name: "Transaction", path: transactionsBasePath, root: "transaction"}
+
 // Transaction represents a OnApp Transaction
 type Transaction struct {
 	Action                 string                 `json:"action,omitempty"`
@@ -72,59 +83,110 @@ type Transaction struct {
 	Params                 map[string]interface{} `json:"params,omitempty"`
 }
 
-type transactionRoot struct {
-	Transaction *Transaction `json:"transaction"`
+// ErrTransactionNotFound is returned when no transaction matches the filter.
+var ErrTransactionNotFound = errors.New("onappgo: transaction not found")
+
+// TransactionFilter selects transactions by their fields, zero value fields
+// are not compared.
+type TransactionFilter struct {
+	Action               string
+	AssociatedObjectID   int
+	AssociatedObjectType string
+	ParentID             int
+	ParentType           string
+	ChainID              int
+	Status               string
+
+	// Actions matches transactions with any of the actions, for requests the
+	// API schedules different transactions for.
+	Actions []string
+
+	// CreatedAfter skips transactions created before that time, ListByFilter
+	// also stops paging once it reaches them.
+	CreatedAfter time.Time
 }
 
-// List all transactions
-func (s *TransactionsServiceOp) List(ctx context.Context, opt *ListOptions) ([]Transaction, *Response, error) {
-	path := transactionsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
+// Match check if transaction fits the filter
+func (f *TransactionFilter) Match(trx *Transaction) bool {
+	switch {
+	case f.Action != "" && f.Action != trx.Action,
+		f.AssociatedObjectID != 0 && f.AssociatedObjectID != trx.AssociatedObjectID,
+		f.AssociatedObjectType != "" && f.AssociatedObjectType != trx.AssociatedObjectType,
+		f.ParentID != 0 && f.ParentID != trx.ParentID,
+		f.ParentType != "" && f.ParentType != trx.ParentType,
+		f.ChainID != 0 && f.ChainID != trx.ChainID,
+		f.Status != "" && f.Status != trx.Status:
+		return false
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
+	if len(f.Actions) > 0 && !matchAction(f.Actions, trx.Action) {
+		return false
 	}
 
-	var out []map[string]Transaction
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
+	if !f.CreatedAfter.IsZero() {
+		if created, ok := trx.CreatedTime(); ok && created.Before(f.CreatedAfter) {
+			return false
+		}
 	}
 
-	trx := make([]Transaction, len(out))
-	for i := range trx {
-		trx[i] = out[i]["transaction"]
+	return true
+}
+
+func matchAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
 	}
 
-	return trx, resp, err
+	return false
 }
 
-// Get an transaction by ID.
-func (s *TransactionsServiceOp) Get(ctx context.Context, id int) (*Transaction, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
+// TransactionWaitOptions specifies the optional parameters to the Wait method.
+type TransactionWaitOptions struct {
+	// Interval between two polls of the transaction status, 5 seconds by default.
+	Interval time.Duration
 
-	path := fmt.Sprintf("%s/%d%s", transactionsBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	// MaxInterval caps the polling interval once Backoff is applied, 1 minute by default.
+	MaxInterval time.Duration
 
-	root := new(transactionRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
+	// Backoff multiplies the polling interval after every poll, values below 1 disable it.
+	Backoff float64
+
+	// SkipChain waits only for the given transaction and ignores the rest of its chain.
+	SkipChain bool
+}
+
+// TransactionError is returned by Wait when a transaction of the tracked chain
+// finished with 'failed' or 'cancelled' status.
+type TransactionError struct {
+	Transaction *Transaction
+}
+
+func (e *TransactionError) Error() string {
+	return fmt.Sprintf("transaction %d [%s] of %s %d finished with status '%s'",
+		e.Transaction.ID, e.Transaction.Action, e.Transaction.AssociatedObjectType,
+		e.Transaction.AssociatedObjectID, e.Transaction.Status)
+}
+
+// List all transactions
+func (s *TransactionsServiceOp) List(ctx context.Context, opt *ListOptions) ([]Transaction, *Response, error) {
+	return transactionsCRUD.list(ctx, s.client, opt)
+}
 
-	return root.Transaction, resp, err
+// ListAll walks all pages of Transactions.
+func (s *TransactionsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]Transaction, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
+}
+
+// Get an transaction by ID.
+func (s *TransactionsServiceOp) Get(ctx context.Context, id int) (*Transaction, *Response, error) {
+	return transactionsCRUD.get(ctx, s.client, id)
 }
 
 // ListByGroup return group of transactions depended by action
+//
+// Deprecated: use ListByFilter with a TransactionFilter.
 func (s *TransactionsServiceOp) ListByGroup(ctx context.Context, meta interface{}, revers bool, opt *ListOptions) ([]Transaction, *Response, error) {
 	var associatedObjectID, parentID int
 	var associatedObjectType, parentType string
@@ -231,7 +293,49 @@ func (s *TransactionsServiceOp) ListByGroup(ctx context.Context, meta interface{
 	return groupList, resp, err
 }
 
+// ListByFilter returns all transactions which match the filter. Transactions are
+// listed newest first, so paging stops at the page which reaches transactions
+// created before filter.CreatedAfter.
+func (s *TransactionsServiceOp) ListByFilter(ctx context.Context, filter *TransactionFilter) ([]Transaction, *Response, error) {
+	if filter == nil {
+		return nil, nil, godo.NewArgError("filter", "cannot be nil")
+	}
+
+	opt := &ListOptions{
+		Page:    1,
+		PerPage: searchTransactions,
+	}
+
+	var res []Transaction
+	for {
+		lst, resp, err := s.List(ctx, opt)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		older := false
+		for i := range lst {
+			if filter.Match(&lst[i]) {
+				res = append(res, lst[i])
+				continue
+			}
+
+			if created, ok := lst[i].CreatedTime(); ok && !filter.CreatedAfter.IsZero() && created.Before(filter.CreatedAfter) {
+				older = true
+			}
+		}
+
+		if len(lst) == 0 || older || resp.Links == nil || resp.Links.IsLastPage() {
+			return res, resp, nil
+		}
+
+		opt.Page++
+	}
+}
+
 // GetByFilter find transaction with specified fields.
+//
+// Deprecated: use ListByFilter with a TransactionFilter.
 func (s *TransactionsServiceOp) GetByFilter(ctx context.Context, filter interface{}, opts *ListOptions) (*Transaction, *Response, error) {
 	lst, resp, err := s.client.Transactions.List(ctx, opts)
 	if err != nil {
@@ -248,6 +352,8 @@ func (s *TransactionsServiceOp) GetByFilter(ctx context.Context, filter interfac
 }
 
 // EqualFilter -
+//
+// Deprecated: use TransactionFilter.Match.
 func (trx *Transaction) EqualFilter(filter interface{}) bool {
 	return trx.equal(filter)
 }
@@ -274,17 +380,178 @@ func (trx *Transaction) equal(filter interface{}) bool {
 	return true
 }
 
-func lastTransaction(ctx context.Context, client *Client, filter interface{}) (*Transaction, *Response, error) {
-	opt := &ListOptions{
-		PerPage: searchTransactions,
+// Wait blocks until the transaction and every transaction chained to it through
+// ChainID or DependentTransactionID are finished. It returns the last transaction
+// of the chain, or a *TransactionError if one of them failed or was cancelled.
+func (s *TransactionsServiceOp) Wait(ctx context.Context, id int, opts *TransactionWaitOptions) (*Transaction, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	if opts == nil {
+		opts = &TransactionWaitOptions{}
+	}
+
+	trx, resp, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	seen := make(map[int]bool)
+	for {
+		trx, resp, err = s.waitFinished(ctx, trx, opts)
+		if err != nil {
+			return trx, resp, err
+		}
+
+		if trx.Unlucky() {
+			return trx, resp, &TransactionError{Transaction: trx}
+		}
+
+		if opts.SkipChain {
+			return trx, resp, nil
+		}
+
+		seen[trx.ID] = true
+
+		next, lstResp, err := s.nextInChain(ctx, trx, seen)
+		if err != nil {
+			return next, lstResp, err
+		}
+
+		if next == nil {
+			return trx, resp, nil
+		}
+
+		trx = next
+	}
+}
+
+// waitFinished polls the transaction until it leaves 'pending' or 'running' status.
+func (s *TransactionsServiceOp) waitFinished(ctx context.Context, trx *Transaction, opts *TransactionWaitOptions) (*Transaction, *Response, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxInterval
+	}
+
+	var resp *Response
+	var err error
+	for !trx.Finished() {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return trx, resp, ctx.Err()
+		case <-timer.C:
+		}
+
+		trx, resp, err = s.Get(ctx, trx.ID)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		if opts.Backoff > 1 {
+			interval = time.Duration(float64(interval) * opts.Backoff)
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+
+	return trx, resp, nil
+}
+
+// nextInChain looks through the transactions created since trx for the next
+// not yet seen transaction of the chain. A failed or cancelled one is reported
+// as an error.
+func (s *TransactionsServiceOp) nextInChain(ctx context.Context, trx *Transaction, seen map[int]bool) (*Transaction, *Response, error) {
+	filter := &TransactionFilter{}
+	if created, ok := trx.CreatedTime(); ok {
+		filter.CreatedAfter = created
+	}
+
+	lst, resp, err := s.ListByFilter(ctx, filter)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	lst, resp, err := client.Transactions.ListByGroup(ctx, filter, false, opt)
-	if lst == nil || err != nil {
-		return nil, nil, err
+	var next *Transaction
+	for i := range lst {
+		cur := &lst[i]
+		if seen[cur.ID] {
+			continue
+		}
+
+		if cur.DependentTransactionID != trx.ID && (trx.ChainID == 0 || cur.ChainID != trx.ChainID) {
+			continue
+		}
+
+		if cur.Unlucky() {
+			return cur, resp, &TransactionError{Transaction: cur}
+		}
+
+		if next == nil || cur.ID < next.ID {
+			next = cur
+		}
 	}
 
-	return &lst[0], resp, err
+	return next, resp, nil
+}
+
+// lastTransaction returns the first transaction matching the filter which was
+// created after the request that got actionResp was started. It is the head
+// of the chain scheduled by that request.
+func lastTransaction(ctx context.Context, client *Client, started time.Time, actionResp *Response, filter *TransactionFilter) (trx *Transaction, resp *Response, err error) {
+	defer func() {
+		client.auditTransaction(actionResp, trx)
+	}()
+
+	filter.CreatedAfter = serverTime(started, actionResp)
+
+	lst, resp, err := client.Transactions.ListByFilter(ctx, filter)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(lst) == 0 {
+		return nil, resp, ErrTransactionNotFound
+	}
+
+	first := &lst[0]
+	for i := range lst {
+		if lst[i].ID < first.ID {
+			first = &lst[i]
+		}
+	}
+
+	return first, resp, nil
+}
+
+// serverTime converts the local time to the control panel clock using the Date
+// header of the response. A second is subtracted since Date has no fractions.
+func serverTime(local time.Time, resp *Response) time.Time {
+	if resp == nil || resp.Response == nil {
+		return local.Add(-time.Second)
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return local.Add(-time.Second)
+	}
+
+	skew := date.Sub(time.Now())
+	return local.Add(skew).Add(-time.Second)
+}
+
+// CreatedTime returns parsed CreatedAt
+func (trx Transaction) CreatedTime() (time.Time, bool) {
+	created, err := time.Parse(time.RFC3339, trx.CreatedAt)
+	return created, err == nil
 }
 
 func (trx Transaction) String() string {
@@ -0,0 +1,192 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testWaitOptions = &TransactionWaitOptions{
+	Interval: time.Millisecond,
+}
+
+func TestTransactions_Wait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/transactions/1.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		status := TransactionRunning
+		if polls > 1 {
+			status = TransactionComplete
+		}
+		polls++
+
+		fmt.Fprintf(w, `{"transaction":{"id":1,"chain_id":7,"status":"%s"}}`, status)
+	})
+
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+			{"transaction":{"id":3,"chain_id":7,"dependent_transaction_id":2,"status":"complete"}},
+			{"transaction":{"id":2,"chain_id":7,"dependent_transaction_id":1,"status":"complete"}},
+			{"transaction":{"id":1,"chain_id":7,"status":"complete"}},
+			{"transaction":{"id":4,"chain_id":8,"status":"pending"}}
+		]`)
+	})
+
+	got, _, err := client.Transactions.Wait(ctx, 1, testWaitOptions)
+	require.NoError(t, err)
+	require.Equal(t, 3, got.ID)
+	require.Equal(t, 3, polls)
+}
+
+func TestTransactions_Wait_chainPastFirstPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := func(d time.Duration) string {
+		return time.Now().UTC().Add(-time.Hour).Add(d).Format(time.RFC3339)
+	}
+
+	mux.HandleFunc("/transactions/10.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"transaction":{"id":10,"chain_id":7,"status":"complete","created_at":"%s"}}`, created(0))
+	})
+
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerPerPage, "2")
+		w.Header().Set(headerTotal, "8")
+		w.Header().Set(headerPage, r.URL.Query().Get("page"))
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":16,"chain_id":8,"status":"running","created_at":"%[1]s"}},
+				{"transaction":{"id":15,"chain_id":8,"status":"complete","created_at":"%[1]s"}}
+			]`, created(3*time.Second))
+		case "2":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":14,"chain_id":9,"status":"pending","created_at":"%s"}},
+				{"transaction":{"id":13,"chain_id":7,"dependent_transaction_id":11,"status":"complete","created_at":"%s"}}
+			]`, created(3*time.Second), created(2*time.Second))
+		case "3":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":11,"chain_id":7,"dependent_transaction_id":10,"status":"complete","created_at":"%s"}},
+				{"transaction":{"id":10,"chain_id":7,"status":"complete","created_at":"%s"}}
+			]`, created(time.Second), created(0))
+		case "4":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":9,"chain_id":6,"status":"complete","created_at":"%[1]s"}},
+				{"transaction":{"id":8,"chain_id":6,"status":"complete","created_at":"%[1]s"}}
+			]`, created(-time.Hour))
+		default:
+			t.Errorf("Unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+
+	got, _, err := client.Transactions.Wait(ctx, 10, testWaitOptions)
+	require.NoError(t, err)
+	require.Equal(t, 13, got.ID)
+}
+
+func TestTransactions_Wait_failedChain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/transactions/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"transaction":{"id":1,"chain_id":7,"status":"complete"}}`)
+	})
+
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"transaction":{"id":2,"chain_id":7,"dependent_transaction_id":1,"status":"failed"}},
+			{"transaction":{"id":1,"chain_id":7,"status":"complete"}}
+		]`)
+	})
+
+	_, _, err := client.Transactions.Wait(ctx, 1, testWaitOptions)
+
+	var trxErr *TransactionError
+	require.True(t, errors.As(err, &trxErr))
+	require.Equal(t, 2, trxErr.Transaction.ID)
+	require.True(t, trxErr.Transaction.Failed())
+}
+
+func TestTransactions_Wait_contextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/transactions/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"transaction":{"id":1,"status":"running"}}`)
+	})
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Transactions.Wait(waitCtx, 1, testWaitOptions)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestVirtualMachineActions_Startup_transaction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serverNow := time.Now().UTC().Add(-time.Hour)
+
+	mux.HandleFunc("/virtual_machines/1/startup.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		w.Header().Set("Date", serverNow.Format(http.TimeFormat))
+	})
+
+	created := func(d time.Duration) string {
+		return serverNow.Add(d).Format(time.RFC3339)
+	}
+
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerPerPage, "2")
+		w.Header().Set(headerTotal, "6")
+		w.Header().Set(headerPage, r.URL.Query().Get("page"))
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":12,"action":"startup_virtual_machine","associated_object_id":2,"associated_object_type":"VirtualMachine","created_at":"%s"}},
+				{"transaction":{"id":11,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":"%s"}}
+			]`, created(2*time.Second), created(time.Second))
+		case "2":
+			fmt.Fprintf(w, `[
+				{"transaction":{"id":10,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":"%s"}},
+				{"transaction":{"id":9,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":"%s"}}
+			]`, created(0), created(-time.Hour))
+		default:
+			t.Errorf("Unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+
+	got, _, err := client.VirtualMachineActions.Startup(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 10, got.ID)
+}
+
+func TestVirtualMachineActions_Startup_transactionNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines/1/startup.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+	})
+
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	_, _, err := client.VirtualMachineActions.Startup(ctx, 1)
+	require.True(t, errors.Is(err, ErrTransactionNotFound))
+}
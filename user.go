@@ -3,7 +3,6 @@ package onappgo
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/digitalocean/godo"
@@ -17,6 +16,7 @@ const usersMakeNewAPIKey string = "users/%d/make_new_api_key"
 // See: https://docs.onapp.com/apim/latest/users
 type UsersService interface {
 	List(context.Context, *ListOptions) ([]User, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]User, *Response, error)
 	Get(context.Context, int) (*User, *Response, error)
 	Create(context.Context, *UserCreateRequest) (*User, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -34,6 +34,8 @@ type UsersServiceOp struct {
 
 var _ UsersService = &UsersServiceOp{}
 
+var usersCRUD = crud[User]{name: "User", path: usersBasePath, root: "user"}
+
 // Infoboxes -
 type Infoboxes struct {
 	DisplayInfoboxes bool     `json:"display_infoboxes,bool"`
@@ -121,91 +123,28 @@ type UserEditRequest struct {
 	RegisteredYubikey bool                `json:"registered_yubikey,bool"`
 }
 
-type userCreateRequestRoot struct {
-	UserCreateRequest *UserCreateRequest `json:"user"`
-}
-
-type userRoot struct {
-	User *User `json:"user"`
-}
-
 func (d UserCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Users.
 func (s *UsersServiceOp) List(ctx context.Context, opt *ListOptions) ([]User, *Response, error) {
-	path := usersBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]User
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]User, len(out))
-	for i := range arr {
-		arr[i] = out[i]["user"]
-	}
+	return usersCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of Users.
+func (s *UsersServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]User, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual User.
 func (s *UsersServiceOp) Get(ctx context.Context, id int) (*User, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", usersBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(userRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.User, resp, err
+	return usersCRUD.get(ctx, s.client, id)
 }
 
 // Create User.
 func (s *UsersServiceOp) Create(ctx context.Context, createRequest *UserCreateRequest) (*User, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
-	}
-
-	path := usersBasePath + apiFormat
-	rootRequest := &userCreateRequestRoot{
-		UserCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("User [Create]  req: ", req)
-
-	root := new(userRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.User, resp, err
+	return usersCRUD.create(ctx, s.client, createRequest)
 }
 
 // UserDeleteRequest -
@@ -220,8 +159,7 @@ func (s *UsersServiceOp) Delete(ctx context.Context, id int, meta interface{}) (
 		return nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf("%s/%d%s", usersBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
+	path, err := addOptions(usersCRUD.member(id), meta)
 	if err != nil {
 		return nil, err
 	}
@@ -231,34 +169,12 @@ func (s *UsersServiceOp) Delete(ctx context.Context, id int, meta interface{}) (
 		Force: 1,
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, opts)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("User [Delete]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return usersCRUD.send(ctx, s.client, "Delete", http.MethodDelete, path, opts)
 }
 
 // Edit User
 func (s *UsersServiceOp) Edit(ctx context.Context, id int, editRequest *UserEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("User [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", usersBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("User [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return usersCRUD.edit(ctx, s.client, id, editRequest)
 }
 
 // MakeNewAPIKey - Make new API key for the User.
@@ -273,7 +189,7 @@ func (s *UsersServiceOp) MakeNewAPIKey(ctx context.Context, id int) (string, *Re
 		return "", nil, err
 	}
 
-	log.Println("User [MakeNewAPIKey]  req: ", req)
+	s.client.debugRequest("User [MakeNewAPIKey]", req)
 
 	var out map[string]interface{}
 	resp, err := s.client.Do(ctx, req, &out)
@@ -2,9 +2,6 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
@@ -16,6 +13,7 @@ const userGroupsBasePath string = "user_groups"
 // See: https://docs.onapp.com/apim/latest/user-groups
 type UserGroupsService interface {
 	List(context.Context, *ListOptions) ([]UserGroup, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]UserGroup, *Response, error)
 	Get(context.Context, int) (*UserGroup, *Response, error)
 	Create(context.Context, *UserGroupCreateRequest) (*UserGroup, *Response, error)
 	Delete(context.Context, int, interface{}) (*Response, error)
@@ -30,6 +28,8 @@ type UserGroupsServiceOp struct {
 
 var _ UserGroupsService = &UserGroupsServiceOp{}
 
+var userGroupsCRUD = crud[UserGroup]{name: "UserGroup", path: userGroupsBasePath, root: "user_group"}
+
 // UserBucket -
 type UserBucket struct {
 	AllowsKms    bool   `json:"allows_kms,bool"`
@@ -81,131 +81,36 @@ type UserGroupEditRequest struct {
 	BillingPlanIDs []int    `json:"billing_plan_ids,omitempty"`
 }
 
-type userGroupCreateRequestRoot struct {
-	UserGroupCreateRequest *UserGroupCreateRequest `json:"user_group"`
-}
-
-type userGroupRoot struct {
-	UserGroup *UserGroup `json:"user_group"`
-}
-
 func (d UserGroupCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all Users.
 func (s *UserGroupsServiceOp) List(ctx context.Context, opt *ListOptions) ([]UserGroup, *Response, error) {
-	path := userGroupsBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]UserGroup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]UserGroup, len(out))
-	for i := range arr {
-		arr[i] = out[i]["user_group"]
-	}
-
-	return arr, resp, err
+	return userGroupsCRUD.list(ctx, s.client, opt)
+}
+
+// ListAll walks all pages of UserGroups.
+func (s *UserGroupsServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]UserGroup, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual UserGroup.
 func (s *UserGroupsServiceOp) Get(ctx context.Context, id int) (*UserGroup, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", userGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(userGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.UserGroup, resp, err
+	return userGroupsCRUD.get(ctx, s.client, id)
 }
 
 // Create UserGroup.
 func (s *UserGroupsServiceOp) Create(ctx context.Context, createRequest *UserGroupCreateRequest) (*UserGroup, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
-	}
-
-	path := userGroupsBasePath + apiFormat
-	rootRequest := &userGroupCreateRequestRoot{
-		UserGroupCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Println("UserGroup [Create]  req: ", req)
-
-	root := new(userGroupRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.UserGroup, resp, err
+	return userGroupsCRUD.create(ctx, s.client, createRequest)
 }
 
 // Delete UserGroup.
 func (s *UserGroupsServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", userGroupsBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("UserGroup [Delete]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return userGroupsCRUD.delete(ctx, s.client, id, meta)
 }
 
 // Edit UserGroup.
 func (s *UserGroupsServiceOp) Edit(ctx context.Context, id int, editRequest *UserGroupEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("UserGroup [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", userGroupsBasePath, id, apiFormat)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("UserGroup [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return userGroupsCRUD.edit(ctx, s.client, id, editRequest)
 }
@@ -2,14 +2,11 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 
 	"github.com/digitalocean/godo"
 )
 
-const userWhiteListsBasePath string = "/users/%d/user_white_lists"
+const userWhiteListsBasePath string = "users/%d/user_white_lists"
 
 // UserWhiteListsService is an interface for interfacing with the UserWhiteList
 // endpoints of the OnApp API
@@ -30,6 +27,8 @@ type UserWhiteListsServiceOp struct {
 
 var _ UserWhiteListsService = &UserWhiteListsServiceOp{}
 
+var userWhiteListsCRUD = crud[UserWhiteList]{name: "UserWhiteList", path: userWhiteListsBasePath, root: "user_white_list"}
+
 // UserWhiteList represents a UserWhiteList
 type UserWhiteList struct {
 	CreatedAt   string `json:"created_at,omitempty"`
@@ -49,132 +48,31 @@ type UserWhiteListCreateRequest struct {
 // UserWhiteListEditRequest represents a request to edit a UserWhiteList
 type UserWhiteListEditRequest UserWhiteListCreateRequest
 
-type userWhiteListCreateRequestRoot struct {
-	UserWhiteListCreateRequest *UserWhiteListCreateRequest `json:"user_white_list"`
-}
-
-type userWhiteListRoot struct {
-	UserWhiteList *UserWhiteList `json:"user_white_list"`
-}
-
 func (d UserWhiteListCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
 // List all UserWhiteLists.
 func (s *UserWhiteListsServiceOp) List(ctx context.Context, userID int, opt *ListOptions) ([]UserWhiteList, *Response, error) {
-	path := fmt.Sprintf(userWhiteListsBasePath, userID) + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]UserWhiteList
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	arr := make([]UserWhiteList, len(out))
-	for i := range arr {
-		arr[i] = out[i]["user_white_list"]
-	}
-
-	return arr, resp, err
+	return userWhiteListsCRUD.at(userID).list(ctx, s.client, opt)
 }
 
 // Get individual UserWhiteList.
 func (s *UserWhiteListsServiceOp) Get(ctx context.Context, userID int, id int) (*UserWhiteList, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(userWhiteListsBasePath, userID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(userWhiteListRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.UserWhiteList, resp, err
+	return userWhiteListsCRUD.at(userID).get(ctx, s.client, id)
 }
 
 // Create UserWhiteList.
 func (s *UserWhiteListsServiceOp) Create(ctx context.Context, userID int, createRequest *UserWhiteListCreateRequest) (*UserWhiteList, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("UserWhiteList createRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(userWhiteListsBasePath, userID) + apiFormat
-	rootRequest := &userWhiteListCreateRequestRoot{
-		UserWhiteListCreateRequest: createRequest,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
-	}
-	fmt.Println("UserWhiteList [Create] req: ", req)
-
-	root := new(userWhiteListRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.UserWhiteList, resp, err
+	return userWhiteListsCRUD.at(userID).create(ctx, s.client, createRequest)
 }
 
 // Delete UserWhiteList.
 func (s *UserWhiteListsServiceOp) Delete(ctx context.Context, userID int, id int, meta interface{}) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf(userWhiteListsBasePath, userID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	fmt.Println("UserWhiteList [Delete] req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return userWhiteListsCRUD.at(userID).delete(ctx, s.client, id, meta)
 }
 
 // Edit UserWhiteList.
 func (s *UserWhiteListsServiceOp) Edit(ctx context.Context, userID int, id int, editRequest *UserWhiteListEditRequest) (*Response, error) {
-	if id < 1 {
-		return nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	if editRequest == nil {
-		return nil, godo.NewArgError("UserWhiteList [Edit] editRequest", "cannot be nil")
-	}
-
-	path := fmt.Sprintf(userWhiteListsBasePath, userID)
-	path = fmt.Sprintf("%s/%d%s", path, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, editRequest)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("UserWhiteList [Edit]  req: ", req)
-
-	return s.client.Do(ctx, req, nil)
+	return userWhiteListsCRUD.at(userID).edit(ctx, s.client, id, editRequest)
 }
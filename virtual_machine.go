@@ -2,24 +2,30 @@ package onappgo
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net/http"
+	"errors"
+	"time"
 
 	"github.com/digitalocean/godo"
 )
 
 const virtualMachineBasePath = "virtual_machines"
+const virtualMachineTransactionsBasePath = "virtual_machines/%d/transactions"
 
 // VirtualMachinesService is an interface for interfacing with the VirtualMachine
 // endpoints of the OnApp API
 // See: https://docs.onapp.com/apim/latest/virtual-servers
 type VirtualMachinesService interface {
 	List(context.Context, *ListOptions) ([]VirtualMachine, *Response, error)
+	ListAll(context.Context, *ListAllOptions) ([]VirtualMachine, *Response, error)
 	Get(context.Context, int) (*VirtualMachine, *Response, error)
 	Create(context.Context, *VirtualMachineCreateRequest) (*VirtualMachine, *Response, error)
 	Delete(context.Context, int, interface{}) (*Transaction, *Response, error)
-	// Edit(context.Context, int, *ListOptions) ([]VirtualMachine, *Response, error)
+	Edit(context.Context, int, *VirtualMachineEditRequest) (*VirtualMachineEditResult, *Response, error)
+
+	Search(context.Context, *VirtualMachineListOptions) ([]VirtualMachine, *Response, error)
+	FindByLabel(context.Context, string) (*VirtualMachine, *Response, error)
+	FindByIP(context.Context, string) (*VirtualMachine, *Response, error)
+	FindByIdentifier(context.Context, string) (*VirtualMachine, *Response, error)
 
 	Backups(context.Context, int, *ListOptions) ([]Backup, *Response, error)
 	Transactions(context.Context, int, *ListOptions) ([]Transaction, *Response, error)
@@ -37,6 +43,8 @@ type VirtualMachinesServiceOp struct {
 
 var _ VirtualMachinesService = &VirtualMachinesServiceOp{}
 
+var virtualMachinesCRUD = crud[VirtualMachine]{name: "VirtualMachine", path: virtualMachineBasePath, root: "virtual_machine"}
+
 // VirtualMachine represent VirtualServer from OnApp API
 type VirtualMachine struct {
 	Acceleration                 bool          `json:"acceleration,bool"`
@@ -167,10 +175,6 @@ type VirtualMachineCreateRequest struct {
 	VirshConsole                     bool                             `json:"virsh_console,bool"`
 }
 
-type virtualMachineCreateRequestRoot struct {
-	VirtualMachineCreateRequest *VirtualMachineCreateRequest `json:"virtual_machine"`
-}
-
 type virtualMachineRoot struct {
 	VirtualMachine *VirtualMachine `json:"virtual_machine"`
 }
@@ -179,113 +183,124 @@ func (d VirtualMachineCreateRequest) String() string {
 	return godo.Stringify(d)
 }
 
-// List all VirtualMachines.
-func (s *VirtualMachinesServiceOp) List(ctx context.Context, opt *ListOptions) ([]VirtualMachine, *Response, error) {
-	path := virtualMachineBasePath + apiFormat
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
+// VirtualMachineEditRequest represents a request to edit a VirtualMachine,
+// zero values are left unchanged
+type VirtualMachineEditRequest struct {
+	Label          string `json:"label,omitempty"`
+	Hostname       string `json:"hostname,omitempty"`
+	AdminNote      string `json:"admin_note,omitempty"`
+	Note           string `json:"note,omitempty"`
+	Cpus           int    `json:"cpus,omitempty"`
+	CPUShares      int    `json:"cpu_shares,omitempty"`
+	CPUSockets     string `json:"cpu_sockets,omitempty"`
+	CPUThreads     int    `json:"cpu_threads,omitempty"`
+	Memory         int    `json:"memory,omitempty"`
+	RateLimit      int    `json:"rate_limit,omitempty"`
+	TimeZone       string `json:"time_zone,omitempty"`
+	AllowMigration *bool  `json:"allow_migration,omitempty"`
+}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+func (d VirtualMachineEditRequest) String() string {
+	return godo.Stringify(d)
+}
 
-	var out []map[string]VirtualMachine
-	resp, err := s.client.Do(ctx, req, &out)
+// VirtualMachineEditResult is the outcome of VirtualMachinesService.Edit
+type VirtualMachineEditResult struct {
+	// Transaction resizing the VirtualMachine, nil if the request changes
+	// no resources
+	Transaction *Transaction
 
-	if err != nil {
-		return nil, resp, err
-	}
+	// RebootRequired reports that the VirtualMachine has to be rebooted to
+	// apply the new CPU or memory size
+	RebootRequired bool
+}
 
-	arr := make([]VirtualMachine, len(out))
-	for i := range arr {
-		arr[i] = out[i]["virtual_machine"]
-	}
+// List all VirtualMachines.
+func (s *VirtualMachinesServiceOp) List(ctx context.Context, opt *ListOptions) ([]VirtualMachine, *Response, error) {
+	return virtualMachinesCRUD.list(ctx, s.client, opt)
+}
 
-	return arr, resp, err
+// ListAll walks all pages of VirtualMachines.
+func (s *VirtualMachinesServiceOp) ListAll(ctx context.Context, opts *ListAllOptions) ([]VirtualMachine, *Response, error) {
+	return ListAllPages(ctx, opts, s.List)
 }
 
 // Get individual VirtualMachine.
 func (s *VirtualMachinesServiceOp) Get(ctx context.Context, id int) (*VirtualMachine, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
-	}
-
-	path := fmt.Sprintf("%s/%d%s", virtualMachineBasePath, id, apiFormat)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	root := new(virtualMachineRoot)
-	resp, err := s.client.Do(ctx, req, root)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return root.VirtualMachine, resp, err
+	return virtualMachinesCRUD.get(ctx, s.client, id)
 }
 
 // Create VirtualMachine.
 func (s *VirtualMachinesServiceOp) Create(ctx context.Context, createRequest *VirtualMachineCreateRequest) (*VirtualMachine, *Response, error) {
-	if createRequest == nil {
-		return nil, nil, godo.NewArgError("createRequest", "cannot be nil")
+	return virtualMachinesCRUD.create(ctx, s.client, createRequest)
+}
+
+// Delete VirtualMachine.
+func (s *VirtualMachinesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Transaction, *Response, error) {
+	filter := &TransactionFilter{
+		AssociatedObjectID:   id,
+		AssociatedObjectType: "VirtualMachine",
 	}
 
-	path := virtualMachineBasePath + apiFormat
-	rootRequest := &virtualMachineCreateRequestRoot{
-		VirtualMachineCreateRequest: createRequest,
+	return virtualMachinesCRUD.deleteTransaction(ctx, s.client, id, meta, filter)
+}
+
+// Edit VirtualMachine. If the request resizes the VirtualMachine the resize
+// transaction is returned along with whether it needs a reboot, see
+// VirtualMachine.ResizeRequiresReboot. The result is also returned when the
+// resize is applied but its transaction cannot be found.
+func (s *VirtualMachinesServiceOp) Edit(ctx context.Context, id int, editRequest *VirtualMachineEditRequest) (*VirtualMachineEditResult, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rootRequest)
-	if err != nil {
-		return nil, nil, err
+	if editRequest == nil {
+		return nil, nil, godo.NewArgError("editRequest", "cannot be nil")
 	}
-	log.Println("VirtualMachine [Create]  req: ", req)
 
-	root := new(virtualMachineRoot)
-	resp, err := s.client.Do(ctx, req, root)
+	vm, resp, err := s.Get(ctx, id)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return root.VirtualMachine, resp, err
-}
-
-// Delete VirtualMachine.
-func (s *VirtualMachinesServiceOp) Delete(ctx context.Context, id int, meta interface{}) (*Transaction, *Response, error) {
-	if id < 1 {
-		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	// Without the template the policy is unknown and a reboot is assumed
+	var template *ImageTemplate
+	if vm.TemplateID > 0 {
+		template, resp, err = s.client.ImageTemplates.Get(ctx, vm.TemplateID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, resp, err
+		}
 	}
 
-	path := fmt.Sprintf("%s/%d%s", virtualMachineBasePath, id, apiFormat)
-	path, err := addOptions(path, meta)
-	if err != nil {
-		return nil, nil, err
+	result := &VirtualMachineEditResult{
+		RebootRequired: vm.ResizeRequiresReboot(template, editRequest),
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, nil, err
+	if !vm.resized(editRequest) {
+		resp, err = virtualMachinesCRUD.edit(ctx, s.client, id, editRequest)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return result, resp, nil
 	}
-	log.Println("VirtualMachine [Delete]  req: ", req)
 
-	resp, err := s.client.Do(ctx, req, nil)
+	started := time.Now()
+	resp, err = virtualMachinesCRUD.edit(withTransactionAudit(ctx), s.client, id, editRequest)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	filter := struct {
-		AssociatedObjectID   int
-		AssociatedObjectType string
-	}{
+	filter := &TransactionFilter{
 		AssociatedObjectID:   id,
 		AssociatedObjectType: "VirtualMachine",
+		Actions:              resizeActions,
 	}
 
-	return lastTransaction(ctx, s.client, filter)
+	// The resize is applied even if its transaction is not found, so the
+	// result is returned along with the error
+	result.Transaction, resp, err = lastTransaction(ctx, s.client, started, resp, filter)
+	return result, resp, err
 }
 
 // Backups lists the backups for a VirtualMachine
@@ -294,30 +309,7 @@ func (s *VirtualMachinesServiceOp) Backups(ctx context.Context, id int, opt *Lis
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	resourceType := "backup"
-	path := fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, resourceType+"s", apiFormat)
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Backup
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	backups := make([]Backup, len(out))
-	for i := range backups {
-		backups[i] = out[i][resourceType]
-	}
-
-	return backups, resp, err
+	return backupsCRUD.in(listOfAllVSBackupsBasePath, id).list(ctx, s.client, opt)
 }
 
 // Transactions lists the transactions for a VirtualMachine.
@@ -326,30 +318,7 @@ func (s *VirtualMachinesServiceOp) Transactions(ctx context.Context, id int, opt
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	resourceType := "transaction"
-	path := fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, resourceType+"s", apiFormat)
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Transaction
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	transactions := make([]Transaction, len(out))
-	for i := range transactions {
-		transactions[i] = out[i][resourceType]
-	}
-
-	return transactions, resp, err
+	return transactionsCRUD.in(virtualMachineTransactionsBasePath, id).list(ctx, s.client, opt)
 }
 
 // Disks lists the disk for a VirtualMachine.
@@ -358,30 +327,7 @@ func (s *VirtualMachinesServiceOp) Disks(ctx context.Context, id int, opt *ListO
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	resourceType := "disk"
-	path := fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, resourceType+"s", apiFormat)
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]Disk
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	disks := make([]Disk, len(out))
-	for i := range disks {
-		disks[i] = out[i][resourceType]
-	}
-
-	return disks, resp, err
+	return disksCRUD.in(virtualMachineDisksBasePath, id).list(ctx, s.client, opt)
 }
 
 // ListNetworkInterfaces a VirtualMachine
@@ -390,30 +336,7 @@ func (s *VirtualMachinesServiceOp) ListNetworkInterfaces(ctx context.Context, id
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	resourceType := "network_interface"
-	path := fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, resourceType+"s", apiFormat)
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]NetworkInterface
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	nets := make([]NetworkInterface, len(out))
-	for i := range nets {
-		nets[i] = out[i][resourceType]
-	}
-
-	return nets, resp, err
+	return networkInterfacesCRUD.in(networkInterfacesBasePath, id).list(ctx, s.client, opt)
 }
 
 // ListFirewallRules a VirtualMachine
@@ -422,28 +345,5 @@ func (s *VirtualMachinesServiceOp) ListFirewallRules(ctx context.Context, id int
 		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
 	}
 
-	resourceType := "firewall_rule"
-	path := fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, resourceType+"s", apiFormat)
-	path, err := addOptions(path, opt)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var out []map[string]FirewallRule
-	resp, err := s.client.Do(ctx, req, &out)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	fwr := make([]FirewallRule, len(out))
-	for i := range fwr {
-		fwr[i] = out[i][resourceType]
-	}
-
-	return fwr, resp, err
+	return firewallRulesCRUD.in(firewallRulesBasePath, id).list(ctx, s.client, opt)
 }
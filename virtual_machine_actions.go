@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/digitalocean/godo"
 )
@@ -26,10 +28,15 @@ type VirtualMachineActionsService interface {
 	FQDN(context.Context, int, string, string) (*Transaction, *Response, error)
 
 	RebuildNetwork(context.Context, int, interface{}) (*Transaction, *Response, error)
+	Rebuild(context.Context, int, *VirtualMachineRebuildRequest) ([]Transaction, *Response, error)
 
 	AssignIPAddress(context.Context, int, interface{}) (*Transaction, *Response, error)
 	UnAssignIPAddress(context.Context, int, int, interface{}) (*Transaction, *Response, error)
 	ListIPAddresses(context.Context, int) (*Transaction, *Response, error)
+
+	HotMigrate(context.Context, int, int, bool) (*Transaction, *Response, error)
+	ColdMigrate(context.Context, int, int) (*Transaction, *Response, error)
+	MigrationDestination(context.Context, int) (*Hypervisor, *Response, error)
 }
 
 // VirtualMachineActionsServiceOp handles communication with the VirtualMachine action related
@@ -143,6 +150,94 @@ func (s *VirtualMachineActionsServiceOp) RebuildNetwork(ctx context.Context, id
 	return s.doAction(ctx, id, request, nil, opts)
 }
 
+// VirtualMachineRebuildRequest represents a request to reinstall a
+// VirtualMachine from a template, it keeps its ID and IP addresses
+type VirtualMachineRebuildRequest struct {
+	TemplateID int `json:"template_id,omitempty"`
+
+	// 1 starts the VirtualMachine once it is built
+	RequiredStartup   int    `json:"required_startup"`
+	LicensingServerID int    `json:"licensing_server_id,omitempty"`
+	LicensingType     string `json:"licensing_type,omitempty"`
+	LicensingKey      string `json:"licensing_key,omitempty"`
+}
+
+func (d VirtualMachineRebuildRequest) String() string {
+	return godo.Stringify(d)
+}
+
+type rootRebuild struct {
+	Rebuild *VirtualMachineRebuildRequest `json:"virtual_machine"`
+}
+
+// Rebuild a VirtualMachine from the template of the request. The template has
+// to fit the disk and memory size of the VirtualMachine. It returns the
+// transactions of the build chain, oldest first.
+func (s *VirtualMachineActionsServiceOp) Rebuild(ctx context.Context, id int, rebuildRequest *VirtualMachineRebuildRequest) ([]Transaction, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	if rebuildRequest == nil {
+		return nil, nil, godo.NewArgError("rebuildRequest", "cannot be nil")
+	}
+
+	if rebuildRequest.TemplateID < 1 {
+		return nil, nil, godo.NewArgError("TemplateID", "cannot be less than 1")
+	}
+
+	vm, resp, err := s.client.VirtualMachines.Get(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	template, resp, err := s.client.ImageTemplates.Get(ctx, rebuildRequest.TemplateID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if template.MinDiskSize > vm.TotalDiskSize {
+		return nil, nil, godo.NewArgError("TemplateID",
+			fmt.Sprintf("requires %d GB of disk, the VirtualMachine has %d GB", template.MinDiskSize, vm.TotalDiskSize))
+	}
+
+	if template.MinMemorySize > vm.Memory {
+		return nil, nil, godo.NewArgError("TemplateID",
+			fmt.Sprintf("requires %d MB of memory, the VirtualMachine has %d MB", template.MinMemorySize, vm.Memory))
+	}
+
+	request := &ActionRequest{"method": http.MethodPost, "type": "build", "action": "build_virtual_machine"}
+	head, resp, err := s.doAction(ctx, id, request, &rootRebuild{Rebuild: rebuildRequest}, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.chain(ctx, head, resp)
+}
+
+// chain lists the transactions of the chain started by head, oldest first.
+func (s *VirtualMachineActionsServiceOp) chain(ctx context.Context, head *Transaction, resp *Response) ([]Transaction, *Response, error) {
+	created, ok := head.CreatedTime()
+	if head.ChainID == 0 || !ok {
+		return []Transaction{*head}, resp, nil
+	}
+
+	filter := &TransactionFilter{
+		AssociatedObjectID:   head.AssociatedObjectID,
+		AssociatedObjectType: head.AssociatedObjectType,
+		ChainID:              head.ChainID,
+		CreatedAfter:         created,
+	}
+
+	lst, resp, err := s.client.Transactions.ListByFilter(ctx, filter)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sort.Slice(lst, func(i, j int) bool { return lst[i].ID < lst[j].ID })
+	return lst, resp, nil
+}
+
 type rootIPAddress struct {
 	AssignIPAddress *AssignIPAddress `json:"ip_address"`
 }
@@ -203,23 +298,27 @@ func (s *VirtualMachineActionsServiceOp) doAction(ctx context.Context, id int,
 	if err != nil {
 		return nil, nil, err
 	}
+	s.client.debugRequest(fmt.Sprintf("VirtualMachineActions [%s]", (*request)["type"]), req)
 
-	resp, err := s.client.Do(ctx, req, nil)
+	started := time.Now()
+	resp, err := s.client.Do(withTransactionAudit(ctx), req, nil)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	filter := struct {
-		Action               string
-		AssociatedObjectID   int
-		AssociatedObjectType string
-	}{
-		Action:               (*request)["action"].(string),
+	filter := &TransactionFilter{
 		AssociatedObjectID:   id,
 		AssociatedObjectType: "VirtualMachine",
 	}
 
-	return lastTransaction(ctx, s.client, filter)
+	switch action := (*request)["action"].(type) {
+	case string:
+		filter.Action = action
+	case []string:
+		filter.Actions = action
+	}
+
+	return lastTransaction(ctx, s.client, started, resp, filter)
 }
 
 func virtualMachineActionPath(id int, request *ActionRequest) (string, error) {
@@ -241,10 +340,7 @@ func virtualMachineActionPath(id int, request *ActionRequest) (string, error) {
 
 		// url - /virtual_machines/:virtual_machine_id/ip_addresses/:id.json
 		ipAddressID := (*request)["ip_address_id"].(int)
-		res := fmt.Sprintf("%s/%d/%s/%d%s", virtualMachineBasePath, id, path, ipAddressID, apiFormat)
-		fmt.Printf("%s >>> url: %s\n", rtype, res)
-
-		return res, nil
+		return fmt.Sprintf("%s/%d/%s/%d%s", virtualMachineBasePath, id, path, ipAddressID, apiFormat), nil
 	}
 
 	return fmt.Sprintf("%s/%d/%s%s", virtualMachineBasePath, id, path, apiFormat), nil
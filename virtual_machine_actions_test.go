@@ -0,0 +1,64 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualMachineActions_Rebuild(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"virtual_machine":{"id":1,"memory":1024,"total_disk_size":20}}`)
+	})
+	mux.HandleFunc("/templates/5.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"image_template":{"id":5,"min_disk_size":10,"min_memory_size":512}}`)
+	})
+	mux.HandleFunc("/templates/6.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"image_template":{"id":6,"min_disk_size":40,"min_memory_size":512}}`)
+	})
+	mux.HandleFunc("/templates/7.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"image_template":{"id":7,"min_disk_size":10,"min_memory_size":2048}}`)
+	})
+
+	var body map[string]map[string]interface{}
+	mux.HandleFunc("/virtual_machines/1/build.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	now := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	before := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+			{"transaction":{"id":12,"chain_id":3,"associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":%[1]q}},
+			{"transaction":{"id":11,"chain_id":3,"associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":%[1]q}},
+			{"transaction":{"id":10,"chain_id":3,"action":"build_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":%[1]q}},
+			{"transaction":{"id":9,"chain_id":2,"action":"build_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":%[2]q}},
+			{"transaction":{"id":8,"chain_id":4,"action":"update_firewall","associated_object_id":1,"associated_object_type":"VirtualMachine","created_at":%[1]q}}
+		]`, now, before)
+	})
+
+	chain, _, err := client.VirtualMachineActions.Rebuild(ctx, 1, &VirtualMachineRebuildRequest{TemplateID: 5, RequiredStartup: 1})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"template_id": 5.0, "required_startup": 1.0}, body["virtual_machine"])
+
+	ids := make([]int, len(chain))
+	for i := range chain {
+		ids[i] = chain[i].ID
+	}
+	require.Equal(t, []int{10, 11, 12}, ids)
+
+	_, _, err = client.VirtualMachineActions.Rebuild(ctx, 1, &VirtualMachineRebuildRequest{TemplateID: 6})
+	require.EqualError(t, err, "TemplateID is invalid because requires 40 GB of disk, the VirtualMachine has 20 GB")
+
+	_, _, err = client.VirtualMachineActions.Rebuild(ctx, 1, &VirtualMachineRebuildRequest{TemplateID: 7})
+	require.EqualError(t, err, "TemplateID is invalid because requires 2048 MB of memory, the VirtualMachine has 1024 MB")
+}
@@ -0,0 +1,121 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+)
+
+// ErrNoMigrationDestination is returned by MigrationDestination when no other
+// hypervisor of the group can take the VirtualMachine.
+var ErrNoMigrationDestination = errors.New("onappgo: no hypervisor to migrate to")
+
+type migrate struct {
+	Destination           int `json:"destination"`
+	ColdMigrateOnRollback int `json:"cold_migrate_on_rollback,omitempty"`
+}
+
+type rootMigrate struct {
+	Migrate *migrate `json:"virtual_machine"`
+}
+
+// HotMigrate moves a running VirtualMachine to the destination hypervisor.
+// With coldFallback the control panel shuts the VirtualMachine down and
+// migrates it cold if the hot migration fails.
+func (s *VirtualMachineActionsServiceOp) HotMigrate(ctx context.Context, id int, destination int, coldFallback bool) (*Transaction, *Response, error) {
+	root := &rootMigrate{
+		Migrate: &migrate{Destination: destination},
+	}
+	if coldFallback {
+		root.Migrate.ColdMigrateOnRollback = 1
+	}
+
+	return s.doMigrate(ctx, id, "hot_migrate", root)
+}
+
+// ColdMigrate moves a VirtualMachine which is shut down to the destination
+// hypervisor.
+func (s *VirtualMachineActionsServiceOp) ColdMigrate(ctx context.Context, id int, destination int) (*Transaction, *Response, error) {
+	root := &rootMigrate{
+		Migrate: &migrate{Destination: destination},
+	}
+
+	return s.doMigrate(ctx, id, "cold_migrate", root)
+}
+
+func (s *VirtualMachineActionsServiceOp) doMigrate(ctx context.Context, id int, rtype string, root *rootMigrate) (*Transaction, *Response, error) {
+	if root.Migrate.Destination < 1 {
+		return nil, nil, godo.NewArgError("destination", "cannot be less than 1")
+	}
+
+	// Hot and cold migrations share the endpoint and the API picks the
+	// transaction by the VirtualMachine state, so any migration is accepted
+	request := &ActionRequest{"method": http.MethodPost, "type": rtype, "path": "migration",
+		"action": []string{"hot_migrate", "cold_migrate", "migrate"}}
+	return s.doAction(ctx, id, request, root, nil)
+}
+
+// MigrationDestination picks the hypervisor to migrate the VirtualMachine to
+// during maintenance of its current one: the enabled and online hypervisor of
+// the same group with the most free memory that fits the VirtualMachine.
+func (s *VirtualMachineActionsServiceOp) MigrationDestination(ctx context.Context, id int) (*Hypervisor, *Response, error) {
+	if id < 1 {
+		return nil, nil, godo.NewArgError("id", "cannot be less than 1")
+	}
+
+	vm, resp, err := s.client.VirtualMachines.Get(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	hypervisors, resp, err := s.client.Hypervisors.ListAll(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var source *Hypervisor
+	for i := range hypervisors {
+		if hypervisors[i].ID == vm.HypervisorID {
+			source = &hypervisors[i]
+			break
+		}
+	}
+
+	if source == nil {
+		return nil, resp, ErrNoMigrationDestination
+	}
+
+	var best *Hypervisor
+	for i := range hypervisors {
+		hv := &hypervisors[i]
+		switch {
+		case hv.ID == source.ID,
+			hv.HypervisorGroupID != source.HypervisorGroupID,
+			!hv.Enabled || !hv.Online,
+			hv.freeMemory() < vm.Memory:
+			continue
+		}
+
+		if best == nil || hv.freeMemory() > best.freeMemory() {
+			best = hv
+		}
+	}
+
+	if best == nil {
+		return nil, resp, ErrNoMigrationDestination
+	}
+
+	return best, resp, nil
+}
+
+// freeMemory is the memory in MB left for VirtualMachines, older control
+// panels only report free_mem.
+func (hv *Hypervisor) freeMemory() int {
+	if hv.FreeMemory != 0 {
+		return hv.FreeMemory
+	}
+
+	return hv.FreeMem
+}
@@ -0,0 +1,71 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualMachineActions_HotMigrate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]map[string]int
+	mux.HandleFunc("/virtual_machines/1/migration.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"transaction":{"id":4,"action":"hot_migrate","associated_object_id":1,"associated_object_type":"VirtualMachine"}},
+			{"transaction":{"id":3,"action":"update_firewall","associated_object_id":1,"associated_object_type":"VirtualMachine"}}
+		]`)
+	})
+
+	trx, _, err := client.VirtualMachineActions.HotMigrate(ctx, 1, 3, true)
+	require.NoError(t, err)
+	require.Equal(t, 4, trx.ID)
+	require.Equal(t, map[string]int{"destination": 3, "cold_migrate_on_rollback": 1}, body["virtual_machine"])
+
+	_, _, err = client.VirtualMachineActions.ColdMigrate(ctx, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"destination": 3}, body["virtual_machine"])
+
+	_, _, err = client.VirtualMachineActions.ColdMigrate(ctx, 1, 0)
+	require.EqualError(t, err, "destination is invalid because cannot be less than 1")
+}
+
+func TestVirtualMachineActions_MigrationDestination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"virtual_machine":{"id":1,"hypervisor_id":1,"memory":2048}}`)
+	})
+
+	hypervisors := `[
+		{"hypervisor":{"id":1,"hypervisor_group_id":5,"enabled":true,"online":true,"free_memory":9000}},
+		{"hypervisor":{"id":2,"hypervisor_group_id":5,"enabled":true,"online":true,"free_memory":4096}},
+		{"hypervisor":{"id":3,"hypervisor_group_id":5,"enabled":true,"online":true,"free_mem":8192}},
+		{"hypervisor":{"id":4,"hypervisor_group_id":5,"enabled":false,"online":true,"free_memory":16384}},
+		{"hypervisor":{"id":5,"hypervisor_group_id":6,"enabled":true,"online":true,"free_memory":16384}},
+		{"hypervisor":{"id":6,"hypervisor_group_id":5,"enabled":true,"online":true,"free_memory":1024}}
+	]`
+	mux.HandleFunc("/settings/hypervisors.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, hypervisors)
+	})
+
+	hv, _, err := client.VirtualMachineActions.MigrationDestination(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, hv.ID)
+
+	hypervisors = `[{"hypervisor":{"id":6,"hypervisor_group_id":5,"enabled":true,"online":true,"free_memory":1024}}]`
+
+	_, _, err = client.VirtualMachineActions.MigrationDestination(ctx, 1)
+	require.True(t, errors.Is(err, ErrNoMigrationDestination))
+}
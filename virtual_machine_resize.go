@@ -0,0 +1,104 @@
+package onappgo
+
+import (
+	"strings"
+)
+
+const (
+	resizeIncrease = "increase"
+	resizeDecrease = "decrease"
+)
+
+// resizeActions are the transactions the API schedules to resize a
+// VirtualMachine with or without a reboot.
+var resizeActions = []string{"resize_virtual_machine", "resize_vm_without_reboot"}
+
+// resized reports whether the request changes the CPU or memory resources of
+// the VirtualMachine.
+func (vm *VirtualMachine) resized(r *VirtualMachineEditRequest) bool {
+	return vm.cpusResized(r) || vm.memoryResized(r) ||
+		(r.CPUShares != 0 && r.CPUShares != vm.CPUShares)
+}
+
+func (vm *VirtualMachine) cpusResized(r *VirtualMachineEditRequest) bool {
+	return r.Cpus != 0 && r.Cpus != vm.Cpus
+}
+
+func (vm *VirtualMachine) memoryResized(r *VirtualMachineEditRequest) bool {
+	return r.Memory != 0 && r.Memory != vm.Memory
+}
+
+// ResizeRequiresReboot reports whether applying the request to the running
+// VirtualMachine requires a reboot. A resize is applied online only if the
+// VirtualMachine allows hot migration, hot adds the resized resource and the
+// template it was built from allows the resize without reboot. CPU shares
+// are always applied online and a VirtualMachine which is not booted picks
+// up the new size on startup.
+func (vm *VirtualMachine) ResizeRequiresReboot(template *ImageTemplate, r *VirtualMachineEditRequest) bool {
+	cpus, memory := vm.cpusResized(r), vm.memoryResized(r)
+	if !vm.Booted || (!cpus && !memory) {
+		return false
+	}
+
+	if !vm.AllowedHotMigrate || template == nil || !template.AllowResizeWithoutReboot {
+		return true
+	}
+
+	if cpus && (!hotAddAllowed(vm.HotAddCPU) ||
+		!template.resizeWithoutReboot(vm.HypervisorType, "cpus", resizeDirection(vm.Cpus, r.Cpus))) {
+		return true
+	}
+
+	if memory && (!hotAddAllowed(vm.HotAddMemory) ||
+		!template.resizeWithoutReboot(vm.HypervisorType, "memory", resizeDirection(vm.Memory, r.Memory))) {
+		return true
+	}
+
+	return false
+}
+
+// hotAddAllowed reports whether a hot add flag of the VirtualMachine allows
+// the resize, the API leaves the flag empty when the hypervisor has no such
+// setting.
+func hotAddAllowed(flag string) bool {
+	switch strings.ToLower(flag) {
+	case "false", "0", "no":
+		return false
+	}
+
+	return true
+}
+
+func resizeDirection(from, to int) string {
+	if to < from {
+		return resizeDecrease
+	}
+
+	return resizeIncrease
+}
+
+// resizeWithoutReboot looks up the policy of the resource by hypervisor type,
+// e.g. {"kvm": {"cpus": {"increase": true, "decrease": false}}}. A policy may
+// also be a single boolean for both directions. Missing policies do not
+// restrict AllowResizeWithoutReboot.
+func (t *ImageTemplate) resizeWithoutReboot(hypervisorType, resource, direction string) bool {
+	policies, ok := t.ResizeWithoutRebootPolicy[strings.ToLower(hypervisorType)]
+	if !ok {
+		return true
+	}
+
+	policy, ok := policies[resource]
+	if !ok {
+		return true
+	}
+
+	switch p := policy.(type) {
+	case bool:
+		return p
+	case map[string]interface{}:
+		allowed, ok := p[direction].(bool)
+		return !ok || allowed
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package onappgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAmbiguousMatch is returned by the Find methods when more than one
+// resource matches.
+var ErrAmbiguousMatch = errors.New("onappgo: more than one resource matches")
+
+// VirtualMachineListOptions specifies the filters of VirtualMachinesService.Search.
+// The API filters are also checked on the client, so control panels which
+// ignore some of them still return only the matching VirtualMachines.
+type VirtualMachineListOptions struct {
+	// PerPage is the page size of the listing, Page is ignored
+	ListOptions
+
+	// Query searches the label, hostname and IP addresses, it is only
+	// evaluated by the API
+	Query string `url:"q,omitempty"`
+
+	UserID       int    `url:"user_id,omitempty"`
+	HypervisorID int    `url:"hypervisor_id,omitempty"`
+	State        string `url:"state,omitempty"`
+
+	// Criteria the API cannot filter on, checked on the client only
+	Label      string                     `url:"-"`
+	Hostname   string                     `url:"-"`
+	IPAddress  string                     `url:"-"`
+	Identifier string                     `url:"-"`
+	Match      func(*VirtualMachine) bool `url:"-"`
+}
+
+// matches applies the client side filter.
+func (o *VirtualMachineListOptions) matches(vm *VirtualMachine) bool {
+	switch {
+	case o.UserID != 0 && o.UserID != vm.UserID,
+		o.HypervisorID != 0 && o.HypervisorID != vm.HypervisorID,
+		o.State != "" && o.State != vm.State,
+		o.Label != "" && o.Label != vm.Label,
+		o.Hostname != "" && !strings.EqualFold(o.Hostname, vm.Hostname),
+		o.IPAddress != "" && !vm.hasIPAddress(o.IPAddress),
+		o.Identifier != "" && o.Identifier != vm.Identifier,
+		o.Match != nil && !o.Match(vm):
+		return false
+	}
+
+	return true
+}
+
+func (vm *VirtualMachine) hasIPAddress(address string) bool {
+	for _, ip := range vm.IPAddresses {
+		if ip.IPAddress.Address == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Search walks all pages of VirtualMachines matching the options.
+func (s *VirtualMachinesServiceOp) Search(ctx context.Context, opts *VirtualMachineListOptions) ([]VirtualMachine, *Response, error) {
+	if opts == nil {
+		opts = &VirtualMachineListOptions{}
+	}
+
+	list := func(ctx context.Context, opt *ListOptions) ([]VirtualMachine, *Response, error) {
+		pageOpts := *opts
+		pageOpts.ListOptions = *opt
+		return virtualMachinesCRUD.list(ctx, s.client, &pageOpts)
+	}
+
+	all, resp, err := ListAllPages(ctx, &ListAllOptions{PerPage: opts.PerPage}, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var res []VirtualMachine
+	for i := range all {
+		if opts.matches(&all[i]) {
+			res = append(res, all[i])
+		}
+	}
+
+	return res, resp, nil
+}
+
+// FindByLabel returns the VirtualMachine with the label.
+func (s *VirtualMachinesServiceOp) FindByLabel(ctx context.Context, label string) (*VirtualMachine, *Response, error) {
+	return s.findOne(ctx, "label "+label, &VirtualMachineListOptions{Query: label, Label: label})
+}
+
+// FindByIP returns the VirtualMachine the IP address is assigned to.
+func (s *VirtualMachinesServiceOp) FindByIP(ctx context.Context, address string) (*VirtualMachine, *Response, error) {
+	return s.findOne(ctx, "IP address "+address, &VirtualMachineListOptions{Query: address, IPAddress: address})
+}
+
+// FindByIdentifier returns the VirtualMachine with the identifier.
+func (s *VirtualMachinesServiceOp) FindByIdentifier(ctx context.Context, identifier string) (*VirtualMachine, *Response, error) {
+	return s.findOne(ctx, "identifier "+identifier, &VirtualMachineListOptions{Identifier: identifier})
+}
+
+// findOne returns the single VirtualMachine matching the options, criteria
+// describes them in the errors.
+func (s *VirtualMachinesServiceOp) findOne(ctx context.Context, criteria string, opts *VirtualMachineListOptions) (*VirtualMachine, *Response, error) {
+	lst, resp, err := s.Search(ctx, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	switch len(lst) {
+	case 0:
+		return nil, resp, fmt.Errorf("VirtualMachine with %s: %w", criteria, ErrNotFound)
+	case 1:
+		return &lst[0], resp, nil
+	}
+
+	ids := make([]string, len(lst))
+	for i := range lst {
+		ids[i] = fmt.Sprint(lst[i].ID)
+	}
+
+	return nil, resp, fmt.Errorf("VirtualMachines %s with %s: %w", strings.Join(ids, ", "), criteria, ErrAmbiguousMatch)
+}
@@ -0,0 +1,68 @@
+package onappgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testVirtualMachines = `[
+	{"virtual_machine":{"id":1,"label":"web","identifier":"aaa","user_id":2,"state":"built",
+		"ip_addresses":[{"ip_address":{"address":"10.0.0.1"}}]}},
+	{"virtual_machine":{"id":2,"label":"web-2","identifier":"bbb","user_id":2,"state":"built",
+		"ip_addresses":[{"ip_address":{"address":"10.0.0.2"}}]}},
+	{"virtual_machine":{"id":3,"label":"db","identifier":"ccc","user_id":3,"state":"built",
+		"ip_addresses":[{"ip_address":{"address":"10.0.0.2"}}]}}
+]`
+
+func TestVirtualMachines_Search(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines.json", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"q": "web", "user_id": "2", "page": "1", "per_page": "50"})
+		fmt.Fprint(w, testVirtualMachines)
+	})
+
+	// user_id is ignored by the server and applied on the client
+	lst, _, err := client.VirtualMachines.Search(ctx, &VirtualMachineListOptions{
+		ListOptions: ListOptions{PerPage: 50},
+		Query:       "web",
+		UserID:      2,
+		Match:       func(vm *VirtualMachine) bool { return vm.ID > 1 },
+	})
+	require.NoError(t, err)
+	require.Len(t, lst, 1)
+	require.Equal(t, 2, lst[0].ID)
+}
+
+func TestVirtualMachines_Find(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual_machines.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testVirtualMachines)
+	})
+
+	vm, _, err := client.VirtualMachines.FindByLabel(ctx, "web")
+	require.NoError(t, err)
+	require.Equal(t, 1, vm.ID)
+
+	vm, _, err = client.VirtualMachines.FindByIP(ctx, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, 1, vm.ID)
+
+	vm, _, err = client.VirtualMachines.FindByIdentifier(ctx, "ccc")
+	require.NoError(t, err)
+	require.Equal(t, 3, vm.ID)
+
+	_, _, err = client.VirtualMachines.FindByIP(ctx, "10.0.0.2")
+	require.True(t, errors.Is(err, ErrAmbiguousMatch))
+	require.EqualError(t, err, "VirtualMachines 2, 3 with IP address 10.0.0.2: onappgo: more than one resource matches")
+
+	_, _, err = client.VirtualMachines.FindByLabel(ctx, "mail")
+	require.True(t, errors.Is(err, ErrNotFound))
+}
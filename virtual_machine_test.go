@@ -0,0 +1,128 @@
+package onappgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testVirtualMachine = `{"virtual_machine":{"id":1,"booted":true,"cpus":2,"memory":1024,
+	"allowed_hot_migrate":true,"hot_add_cpu":"true","hot_add_memory":"false",
+	"hypervisor_type":"kvm","template_id":5}}`
+
+const testImageTemplate = `{"image_template":{"id":5,"allow_resize_without_reboot":true,
+	"resize_without_reboot_policy":{"kvm":{"cpus":{"increase":true,"decrease":false}}}}}`
+
+func TestVirtualMachines_Edit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var edited map[string]interface{}
+	mux.HandleFunc("/virtual_machines/1.json", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, testVirtualMachine)
+		case http.MethodPut:
+			edited = nil
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&edited))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	transactions := `[
+		{"transaction":{"id":8,"action":"resize_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine"}},
+		{"transaction":{"id":7,"action":"resize_vm_without_reboot","associated_object_id":1,"associated_object_type":"VirtualMachine"}},
+		{"transaction":{"id":6,"action":"resize_virtual_machine","associated_object_id":2,"associated_object_type":"VirtualMachine"}},
+		{"transaction":{"id":5,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine"}}
+	]`
+	templateStatus := http.StatusOK
+	mux.HandleFunc("/templates/5.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(templateStatus)
+		fmt.Fprint(w, testImageTemplate)
+	})
+	mux.HandleFunc("/transactions.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, transactions)
+	})
+
+	result, _, err := client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Cpus: 4, CPUShares: 50})
+	require.NoError(t, err)
+	require.False(t, result.RebootRequired)
+	require.Equal(t, 7, result.Transaction.ID)
+	require.Equal(t, map[string]interface{}{"cpus": 4.0, "cpu_shares": 50.0}, edited)
+
+	result, _, err = client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Memory: 2048})
+	require.NoError(t, err)
+	require.True(t, result.RebootRequired)
+
+	result, _, err = client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Label: "web", AllowMigration: Bool(false)})
+	require.NoError(t, err)
+	require.False(t, result.RebootRequired)
+	require.Nil(t, result.Transaction)
+	require.Equal(t, map[string]interface{}{"label": "web", "allow_migration": false}, edited)
+
+	templateStatus = http.StatusNotFound
+	result, _, err = client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Cpus: 4})
+	require.NoError(t, err)
+	require.True(t, result.RebootRequired)
+
+	templateStatus = http.StatusInternalServerError
+	_, resp, err := client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Cpus: 4})
+	require.Error(t, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	templateStatus = http.StatusOK
+	transactions = `[{"transaction":{"id":5,"action":"startup_virtual_machine","associated_object_id":1,"associated_object_type":"VirtualMachine"}}]`
+	result, _, err = client.VirtualMachines.Edit(ctx, 1, &VirtualMachineEditRequest{Memory: 2048})
+	require.True(t, errors.Is(err, ErrTransactionNotFound))
+	require.True(t, result.RebootRequired)
+	require.Nil(t, result.Transaction)
+
+	_, _, err = client.VirtualMachines.Edit(ctx, 1, nil)
+	require.EqualError(t, err, "editRequest is invalid because cannot be nil")
+}
+
+func TestVirtualMachine_ResizeRequiresReboot(t *testing.T) {
+	var vm VirtualMachine
+	var template ImageTemplate
+	require.NoError(t, json.Unmarshal([]byte(testVirtualMachine), &virtualMachineRoot{VirtualMachine: &vm}))
+	require.NoError(t, json.Unmarshal([]byte(testImageTemplate), &struct {
+		ImageTemplate *ImageTemplate `json:"image_template"`
+	}{&template}))
+
+	tests := []struct {
+		name     string
+		vm       func(VirtualMachine) VirtualMachine
+		template *ImageTemplate
+		request  VirtualMachineEditRequest
+		reboot   bool
+	}{
+		{"cpu increase", nil, &template, VirtualMachineEditRequest{Cpus: 4}, false},
+		{"cpu decrease", nil, &template, VirtualMachineEditRequest{Cpus: 1}, true},
+		{"same cpus", nil, &template, VirtualMachineEditRequest{Cpus: 2}, false},
+		{"cpu shares", nil, nil, VirtualMachineEditRequest{CPUShares: 10}, false},
+		{"no hot add memory", nil, &template, VirtualMachineEditRequest{Memory: 2048}, true},
+		{"unknown template", nil, nil, VirtualMachineEditRequest{Cpus: 4}, true},
+		{"no hot migrate", func(vm VirtualMachine) VirtualMachine {
+			vm.AllowedHotMigrate = false
+			return vm
+		}, &template, VirtualMachineEditRequest{Cpus: 4}, true},
+		{"powered off", func(vm VirtualMachine) VirtualMachine {
+			vm.Booted = false
+			return vm
+		}, nil, VirtualMachineEditRequest{Memory: 2048}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := vm
+			if tt.vm != nil {
+				vm = tt.vm(vm)
+			}
+
+			require.Equal(t, tt.reboot, vm.ResizeRequiresReboot(tt.template, &tt.request))
+		})
+	}
+}